@@ -0,0 +1,79 @@
+package duration
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+// Notation selects between the basic and extended forms of the ISO8601
+// alternative duration format.
+type Notation int
+
+const (
+	// Extended separates fields with "-" and ":", e.g. "P0001-02-03T04:05:06".
+	Extended Notation = iota
+
+	// Basic writes fields without separators, e.g. "P00010203T040506".
+	Basic
+)
+
+// altFields lists the fields of the alternative format with their widths and
+// carry-over points.
+var altFields = [...]struct {
+	unit  Unit
+	width int
+	max   float64
+}{
+	{Year, 4, 9999},
+	{Month, 2, 12},
+	{Day, 2, 30},
+	{Hour, 2, 24},
+	{Minute, 2, 60},
+	{Second, 2, 60},
+}
+
+// FormatAlternative returns c in the ISO8601 alternative format, e.g.
+// "P0001-02-03T04:05:06" (Extended) or "P00010203T040506" (Basic). Weeks are
+// converted to days. Only seconds may have a fraction, and no element may
+// exceed its carry-over point (12 months, 30 days, 24 hours, 60 minutes and 60
+// seconds) or ErrRange is returned; use Split to obtain Components from a
+// time.Duration.
+func FormatAlternative(c Components, n Notation, opts ...FormatOption) (string, error) {
+	fc := newFormatConfig(opts)
+	c.Days += 7 * c.Weeks
+
+	b := []byte{'P'}
+	for _, f := range altFields {
+		v := *c.field(f.unit)
+		switch {
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			return "", ErrBadFormat
+		case v < 0:
+			return "", ErrNoNegative
+		case v > f.max:
+			return "", ErrRange
+		case f.unit != Second && v != math.Trunc(v):
+			return "", ErrBadFormat
+		}
+
+		switch {
+		case f.unit == Hour:
+			b = append(b, 'T')
+		case n == Basic:
+		case f.unit == Month || f.unit == Day:
+			b = append(b, '-')
+		case f.unit == Minute || f.unit == Second:
+			b = append(b, ':')
+		}
+
+		var buf [32]byte
+		num := strconv.AppendFloat(buf[:0], v, 'f', -1, 64)
+		b = appendPadded(b, int64(v), f.width)
+		if i := bytes.IndexByte(num, '.'); i != -1 {
+			b = append(b, fc.separator())
+			b = append(b, num[i+1:]...)
+		}
+	}
+	return string(b), nil
+}
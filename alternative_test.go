@@ -0,0 +1,61 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAlternative(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   Components
+		n    Notation
+		opts []FormatOption
+		out  string
+	}{
+		{Components{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, Extended, nil, "P0001-02-03T04:05:06"},
+		{Components{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, Basic, nil, "P00010203T040506"},
+		{Components{}, Extended, nil, "P0000-00-00T00:00:00"},
+		{Components{Seconds: 6.5}, Extended, nil, "P0000-00-00T00:00:06.5"},
+		{Components{Seconds: 6.5}, Basic, []FormatOption{DecimalSeparator(SeparatorComma)}, "P00000000T000006,5"},
+		{Components{Seconds: 6.1}, Extended, nil, "P0000-00-00T00:00:06.1"},
+		{Components{Seconds: 59.999}, Basic, nil, "P00000000T000059.999"},
+		{Split(1100 * time.Millisecond), Extended, nil, "P0000-00-00T00:00:01.1"},
+		{Components{Weeks: 2, Days: 1}, Extended, nil, "P0000-00-15T00:00:00"},
+		{Split(dayTime + 90*time.Minute), Extended, nil, "P0000-00-01T01:30:00"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatAlternative(vec.in, vec.n, vec.opts...)
+		assert.NoError(t, err, vec.out)
+		assert.Equal(t, vec.out, s)
+	}
+}
+
+func TestFormatAlternativeGivenInvalid(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		err error
+	}{
+		{Components{Months: 13}, ErrRange},
+		{Components{Days: 31}, ErrRange},
+		{Components{Hours: 25}, ErrRange},
+		{Components{Years: 10000}, ErrRange},
+		{Split(40 * dayTime), ErrRange},
+		{Components{Hours: 1.5}, ErrBadFormat},
+		{Components{Seconds: math.Inf(1)}, ErrBadFormat},
+		{Components{Minutes: -1}, ErrNoNegative},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatAlternative(vec.in, Extended)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Empty(t, s)
+	}
+}
@@ -0,0 +1,78 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// MonthDayNano is an interval of whole months, whole days and nanoseconds, the
+// layout of the MonthDayNanoInterval type of github.com/apache/arrow-go and
+// the Duration type of github.com/gocql/gocql, to which it converts directly
+// (e.g. arrow.MonthDayNanoInterval(v)). Keeping months and days apart from
+// the time of day lets calendar periods pass through analytics pipelines
+// without being flattened to a fixed length.
+type MonthDayNano struct {
+	Months      int32
+	Days        int32
+	Nanoseconds int64
+}
+
+// MonthDayNano converts c to a MonthDayNano. Years are counted as 12 months
+// and weeks as 7 days; the resulting months and days must be whole numbers,
+// otherwise ErrBadFormat is returned. Hours, minutes and seconds are combined
+// into nanoseconds. Fields may have different signs. Values that do not fit
+// fail with ErrRange.
+func (c Components) MonthDayNano() (MonthDayNano, error) {
+	months, err := wholeInt32(c.Years*12 + c.Months)
+	if err != nil {
+		return MonthDayNano{}, err
+	}
+	days, err := wholeInt32(c.Weeks*7 + c.Days)
+	if err != nil {
+		return MonthDayNano{}, err
+	}
+
+	var ns time.Duration
+	for u := Hour; u < numUnits; u++ {
+		v := *c.field(u)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return MonthDayNano{}, ErrBadFormat
+		}
+		f := math.Round(v * float64(unitTimes[u]))
+		if f >= math.MaxInt64 || f < math.MinInt64 {
+			return MonthDayNano{}, ErrRange
+		}
+		sum := ns + time.Duration(f)
+		if (f > 0 && sum < ns) || (f < 0 && sum > ns) {
+			return MonthDayNano{}, ErrRange
+		}
+		ns = sum
+	}
+	return MonthDayNano{Months: months, Days: days, Nanoseconds: int64(ns)}, nil
+}
+
+// Components returns v as Components. Months and days are returned as
+// stored; the nanoseconds are split into hours, minutes and seconds as by
+// ParsePostgres.
+func (v MonthDayNano) Components() Components {
+	sc := Split(time.Duration(v.Nanoseconds))
+	return Components{
+		Months:  float64(v.Months),
+		Days:    float64(v.Days),
+		Hours:   sc.Days*24 + sc.Hours,
+		Minutes: sc.Minutes,
+		Seconds: sc.Seconds,
+	}
+}
+
+// wholeInt32 converts f to an int32, failing with ErrBadFormat if it is not a
+// whole number and ErrRange if it is out of range.
+func wholeInt32(f float64) (int32, error) {
+	switch {
+	case math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f):
+		return 0, ErrBadFormat
+	case f > math.MaxInt32 || f < math.MinInt32:
+		return 0, ErrRange
+	}
+	return int32(f), nil
+}
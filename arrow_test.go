@@ -0,0 +1,61 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentsMonthDayNano(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out MonthDayNano
+		err error
+	}{
+		{Components{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}, MonthDayNano{14, 3, 14706500000000}, nil},
+		{Components{Weeks: 2, Days: 1}, MonthDayNano{0, 15, 0}, nil},
+		{Components{Months: -1, Hours: 2}, MonthDayNano{-1, 0, 7200000000000}, nil},
+		{Components{Seconds: 0.000000001}, MonthDayNano{0, 0, 1}, nil},
+		{Components{}, MonthDayNano{}, nil},
+		{Components{Years: 0.5, Weeks: 0.5, Days: 0.5}, MonthDayNano{6, 4, 0}, nil},
+		{Components{Years: 0.1}, MonthDayNano{}, ErrBadFormat},
+		{Components{Days: 1.5}, MonthDayNano{}, ErrBadFormat},
+		{Components{Seconds: math.NaN()}, MonthDayNano{}, ErrBadFormat},
+		{Components{Months: math.Inf(1)}, MonthDayNano{}, ErrBadFormat},
+		{Components{Months: 1 << 31}, MonthDayNano{}, ErrRange},
+		{Components{Hours: 3e6}, MonthDayNano{}, ErrRange},
+		{Components{Hours: 2.5e6, Minutes: 5e6}, MonthDayNano{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		v, err := vec.in.MonthDayNano()
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.in)
+		assert.Equal(t, vec.out, v, "%+v", vec.in)
+	}
+}
+
+func TestMonthDayNanoComponents(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  MonthDayNano
+		out Components
+	}{
+		{MonthDayNano{14, 3, 14706500000000}, Components{Months: 14, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}},
+		{MonthDayNano{0, 0, 90000000000000}, Components{Hours: 25}},
+		{MonthDayNano{-1, 2, -1000000000}, Components{Months: -1, Days: 2, Seconds: -1}},
+		{MonthDayNano{}, Components{}},
+	}
+
+	for _, vec := range vecs {
+		c := vec.in.Components()
+		assert.Equal(t, vec.out, c, "%+v", vec.in)
+
+		v, err := c.MonthDayNano()
+		assert.NoError(t, err)
+		assert.Equal(t, vec.in, v)
+	}
+}
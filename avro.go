@@ -0,0 +1,56 @@
+package duration
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// avroIntervalSize is the size of the interval written by FormatAvroInterval.
+const avroIntervalSize = 12
+
+// FormatAvroInterval returns c in the 12-byte layout of the Avro "duration"
+// and Parquet INTERVAL logical types: unsigned little-endian 32-bit counts of
+// months, days and milliseconds. Years are counted as 12 months and weeks as
+// 7 days; the resulting months and days must be whole numbers, otherwise
+// ErrBadFormat is returned. Hours, minutes and seconds are combined and
+// rounded to the nearest millisecond. Negative counts fail with
+// ErrNoNegative and counts that do not fit with ErrRange.
+func FormatAvroInterval(c Components) ([]byte, error) {
+	secs := c.Hours*3600 + c.Minutes*60 + c.Seconds
+	counts := [...]float64{c.Years*12 + c.Months, c.Weeks*7 + c.Days, math.Round(secs * 1e3)}
+
+	b := make([]byte, 0, avroIntervalSize)
+	for i, f := range counts {
+		switch {
+		case math.IsNaN(f) || math.IsInf(f, 0) || i < 2 && f != math.Trunc(f):
+			return nil, ErrBadFormat
+		case f < 0:
+			return nil, ErrNoNegative
+		case f > math.MaxUint32:
+			return nil, ErrRange
+		}
+		b = binary.LittleEndian.AppendUint32(b, uint32(f))
+	}
+	return b, nil
+}
+
+// ParseAvroInterval parses the 12-byte layout written by FormatAvroInterval.
+// Months and days are returned as stored; the milliseconds are split into
+// hours, minutes and seconds as by ParsePostgres. Data of any other length
+// fails with ErrBadFormat.
+func ParseAvroInterval(b []byte) (Components, error) {
+	if len(b) != avroIntervalSize {
+		return Components{}, ErrBadFormat
+	}
+
+	ms := binary.LittleEndian.Uint32(b[8:])
+	sc := Split(time.Duration(ms) * time.Millisecond)
+	return Components{
+		Months:  float64(binary.LittleEndian.Uint32(b)),
+		Days:    float64(binary.LittleEndian.Uint32(b[4:])),
+		Hours:   sc.Days*24 + sc.Hours,
+		Minutes: sc.Minutes,
+		Seconds: sc.Seconds,
+	}, nil
+}
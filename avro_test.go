@@ -0,0 +1,65 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAvroInterval(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out []byte
+		err error
+	}{
+		{Components{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}, []byte{14, 0, 0, 0, 3, 0, 0, 0, 0x44, 0x67, 0xe0, 0}, nil},
+		{Components{Weeks: 2, Days: 1}, []byte{0, 0, 0, 0, 15, 0, 0, 0, 0, 0, 0, 0}, nil},
+		{Components{Seconds: 0.0015}, []byte{0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0}, nil},
+		{Components{Hours: 1, Minutes: -30}, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0x40, 0x77, 0x1b, 0}, nil},
+		{Components{Months: math.MaxUint32}, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0}, nil},
+		{Components{}, make([]byte, 12), nil},
+		{Components{Months: 0.5}, nil, ErrBadFormat},
+		{Components{Seconds: math.NaN()}, nil, ErrBadFormat},
+		{Components{Days: -1}, nil, ErrNoNegative},
+		{Components{Seconds: -0.5}, nil, ErrNoNegative},
+		{Components{Months: 1 << 32}, nil, ErrRange},
+		{Components{Hours: 1200}, nil, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		b, err := FormatAvroInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.in)
+		assert.Equal(t, vec.out, b, "%+v", vec.in)
+	}
+}
+
+func TestParseAvroInterval(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  []byte
+		out Components
+		err error
+	}{
+		{[]byte{14, 0, 0, 0, 3, 0, 0, 0, 0x44, 0x67, 0xe0, 0}, Components{Months: 14, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}, nil},
+		{[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}, Components{Hours: 1193, Minutes: 2, Seconds: 47.295}, nil},
+		{make([]byte, 12), Components{}, nil},
+		{make([]byte, 11), Components{}, ErrBadFormat},
+		{nil, Components{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParseAvroInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%v", vec.in)
+		assert.Equal(t, vec.out, c, "%v", vec.in)
+
+		if err == nil {
+			b, err := FormatAvroInterval(c)
+			assert.NoError(t, err)
+			assert.Equal(t, vec.in, b)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package duration
+
+import "time"
+
+// azureGrains are the timeGrain values accepted by Azure Monitor, shortest
+// first.
+var azureGrains = []time.Duration{
+	time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 6 * time.Hour, 12 * time.Hour, dayTime,
+}
+
+// SnapAzureTimeGrain returns the Azure Monitor timeGrain nearest to d, one of
+// PT1M, PT5M, PT15M, PT30M, PT1H, PT6H, PT12H and P1D, so that arbitrary
+// durations can be used to query metrics. Durations halfway between two
+// grains snap to the longer one, and durations outside the range to the
+// shortest or longest grain. Write the result with the "azure" profile.
+func SnapAzureTimeGrain(d time.Duration) time.Duration {
+	for i, g := range azureGrains[:len(azureGrains)-1] {
+		next := azureGrains[i+1]
+		if d < g+(next-g)/2 {
+			return g
+		}
+	}
+	return azureGrains[len(azureGrains)-1]
+}
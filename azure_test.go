@@ -0,0 +1,42 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapAzureTimeGrain(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out time.Duration
+	}{
+		{0, time.Minute},
+		{-time.Hour, time.Minute},
+		{30 * time.Second, time.Minute},
+		{time.Minute, time.Minute},
+		{2 * time.Minute, time.Minute},
+		{3 * time.Minute, 5 * time.Minute},
+		{10 * time.Minute, 15 * time.Minute},
+		{20 * time.Minute, 15 * time.Minute},
+		{45 * time.Minute, time.Hour},
+		{3 * time.Hour, time.Hour},
+		{4 * time.Hour, 6 * time.Hour},
+		{18 * time.Hour, dayTime},
+		{weekTime, dayTime},
+	}
+
+	azure, _ := LookupProfile("azure")
+	for _, vec := range vecs {
+		d := SnapAzureTimeGrain(vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+
+		s, err := azure.Format(d)
+		assert.NoError(t, err)
+		_, err = azure.Parse(s)
+		assert.NoError(t, err, s)
+	}
+}
@@ -0,0 +1,28 @@
+package duration
+
+import "reflect"
+
+// UnmarshalParam implements the BindUnmarshaler interfaces of
+// github.com/gin-gonic/gin/binding and github.com/labstack/echo, so that
+// query, form and path parameters such as "?window=PT15M" bind directly into
+// Duration fields. It parses param as UnmarshalText does; invalid values are
+// reported by the binder, which echo turns into a 400 response.
+func (d *Duration) UnmarshalParam(param string) error {
+	return d.UnmarshalText([]byte(param))
+}
+
+// SchemaConverter converts an ISO8601 string into a Duration for
+// github.com/gorilla/schema, which has no support for encoding.TextUnmarshaler.
+// Register it with the decoder:
+//
+//	decoder.RegisterConverter(duration.Duration(0), duration.SchemaConverter)
+//
+// Invalid values yield the zero reflect.Value, which the decoder reports as a
+// schema.ConversionError for the field.
+func SchemaConverter(value string) reflect.Value {
+	var d Duration
+	if d.UnmarshalText([]byte(value)) != nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(d)
+}
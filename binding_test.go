@@ -0,0 +1,43 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationUnmarshalParam(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Duration
+		err error
+	}{
+		{"PT15M", Duration(15 * time.Minute), nil},
+		{"-PT1S", Duration(-time.Second), nil},
+		{"15m", 0, ErrBadFormat},
+		{"", 0, ErrBadFormat},
+		{"P1M", 0, ErrNoMonth},
+	}
+
+	for _, vec := range vecs {
+		var d Duration
+		err := d.UnmarshalParam(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestSchemaConverter(t *testing.T) {
+	t.Parallel()
+
+	v := SchemaConverter("PT15M")
+	if assert.True(t, v.IsValid()) {
+		assert.Equal(t, Duration(15*time.Minute), v.Interface())
+	}
+
+	assert.False(t, SchemaConverter("15m").IsValid())
+	assert.False(t, SchemaConverter("").IsValid())
+}
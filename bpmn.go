@@ -0,0 +1,149 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// TimerType is the kind of a BPMN timer event definition, named after the
+// element that holds its expression.
+type TimerType int
+
+const (
+	// TimeDate fires once at a date and time, e.g. "2019-10-01T12:00:00Z".
+	TimeDate TimerType = iota
+
+	// TimeDuration fires once a duration after the timer is activated, e.g.
+	// "PT10M".
+	TimeDuration
+
+	// TimeCycle fires repeatedly, as given by a repeating interval, e.g.
+	// "R3/PT1H".
+	TimeCycle
+)
+
+var timerTypes = [...]string{
+	TimeDate:     "timeDate",
+	TimeDuration: "timeDuration",
+	TimeCycle:    "timeCycle",
+}
+
+// String returns the name of the BPMN element for t, e.g. "timeCycle".
+func (t TimerType) String() string {
+	if t < 0 || int(t) >= len(timerTypes) {
+		return "unknown"
+	}
+	return timerTypes[t]
+}
+
+// A TimerDefinition is the timer event definition of a BPMN timer event, as
+// executed by workflow engines such as Camunda Zeebe.
+type TimerDefinition struct {
+	Type TimerType
+
+	// Date is the time a TimeDate timer fires.
+	Date time.Time
+
+	// Cycle is the schedule of a TimeCycle timer, or for a TimeDuration timer
+	// the duration alone, repeated once.
+	Cycle RepeatingInterval
+}
+
+// ParseTimer parses and validates the expression of a BPMN timer of type t:
+//
+//   - for TimeDate, a date and time as accepted by ParseInterval for the ends
+//     of an interval, optionally followed by a time zone name in brackets
+//     (e.g. "2019-10-02T08:09:40+02:00[Europe/Berlin]"), in which the time is
+//     then given; a time without a UTC offset is the wall time in that zone;
+//   - for TimeDuration, a duration as accepted by ParseComponents, which must
+//     not be zero;
+//   - for TimeCycle, a repeating interval as accepted by
+//     ParseRepeatingInterval, such as "R/P1D" or
+//     "R3/2019-10-01T12:00:00Z/PT1H".
+//
+// Zero durations, and cycles that repeat a zero-length interval, fail with
+// ErrRange. Cron expressions, which some engines also accept for cycles, fail
+// with ErrBadFormat.
+func ParseTimer(t TimerType, s string) (TimerDefinition, error) {
+	s = strings.TrimSpace(s)
+	def := TimerDefinition{Type: t}
+
+	switch t {
+	case TimeDate:
+		loc, zoned := time.UTC, false
+		if i := strings.IndexByte(s, '['); i != -1 && strings.HasSuffix(s, "]") {
+			var err error
+			if loc, err = time.LoadLocation(s[i+1 : len(s)-1]); err != nil {
+				return TimerDefinition{}, ErrBadFormat
+			}
+			s, zoned = s[:i], true
+		}
+		d, err := parseTimeIn(s, loc)
+		if err != nil {
+			return TimerDefinition{}, err
+		}
+		if zoned {
+			d = d.In(loc)
+		}
+		def.Date = d
+
+	case TimeDuration:
+		c, err := ParseComponents(s, Trim(TrimNone))
+		if err != nil {
+			return TimerDefinition{}, err
+		}
+		if c == (Components{}) {
+			return TimerDefinition{}, ErrRange
+		}
+		def.Cycle = RepeatingInterval{Repeats: 1, Interval: Interval{Period: c}}
+
+	case TimeCycle:
+		r, err := ParseRepeatingInterval(s)
+		if err != nil {
+			return TimerDefinition{}, err
+		}
+		i := r.Interval
+		if i.Start.IsZero() && i.Period == (Components{}) || r.Repeats != 1 && r.step() <= 0 {
+			return TimerDefinition{}, ErrRange
+		}
+		def.Cycle = r
+
+	default:
+		return TimerDefinition{}, ErrBadFormat
+	}
+	return def, nil
+}
+
+// Schedule returns the times at which the timer fires when activated at
+// activated, as a repeating interval with a start: once at Date for TimeDate,
+// once a duration after activation for TimeDuration, and for TimeCycle at the
+// start of the cycle if it has one and otherwise one period after activation
+// and every period after that.
+func (def TimerDefinition) Schedule(activated time.Time) (RepeatingInterval, error) {
+	if def.Type == TimeDate {
+		return RepeatingInterval{Repeats: 1, Interval: Interval{Start: def.Date, End: def.Date}}, nil
+	}
+
+	r := def.Cycle
+	if !r.Interval.Start.IsZero() {
+		return r, nil
+	}
+	first, err := r.Interval.Period.AddTo(activated)
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	return r.StartingAt(first)
+}
+
+// NextFire returns the time at which the timer, activated at activated, next
+// fires after after, or first fires if after is zero, and whether it fires
+// again. As for RepeatingInterval.NextAfter, the time is computed directly, so
+// an engine resuming a long-running cycle need only remember when it last
+// fired.
+func (def TimerDefinition) NextFire(activated, after time.Time) (time.Time, bool) {
+	r, err := def.Schedule(activated)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return r.NextAfter(after)
+}
@@ -0,0 +1,101 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerTypeString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "timeDate", TimeDate.String())
+	assert.Equal(t, "timeDuration", TimeDuration.String())
+	assert.Equal(t, "timeCycle", TimeCycle.String())
+	assert.Equal(t, "unknown", TimerType(3).String())
+}
+
+func TestParseTimer(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	vecs := []struct {
+		typ TimerType
+		in  string
+		out TimerDefinition
+		err error
+	}{
+		{TimeDate, "2019-10-01T12:00:00Z", TimerDefinition{Type: TimeDate, Date: time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)}, nil},
+		{TimeDate, "2019-10-02T08:09:40+02:00[Europe/Berlin]", TimerDefinition{Type: TimeDate, Date: time.Date(2019, 10, 2, 8, 9, 40, 0, berlin)}, nil},
+		{TimeDate, "2019-10-02T08:09:40[Europe/Berlin]", TimerDefinition{Type: TimeDate, Date: time.Date(2019, 10, 2, 8, 9, 40, 0, berlin)}, nil},
+		{TimeDate, "2019-10-02[Europe/Berlin]", TimerDefinition{Type: TimeDate, Date: time.Date(2019, 10, 2, 0, 0, 0, 0, berlin)}, nil},
+		{TimeDate, "2019-10-02T08:09:40Z[Europe/Berlin]", TimerDefinition{Type: TimeDate, Date: time.Date(2019, 10, 2, 10, 9, 40, 0, berlin)}, nil},
+		{TimeDuration, "PT10M", TimerDefinition{Type: TimeDuration, Cycle: RepeatingInterval{1, Interval{Period: Components{Minutes: 10}}}}, nil},
+		{TimeDuration, " P1M ", TimerDefinition{Type: TimeDuration, Cycle: RepeatingInterval{1, Interval{Period: Components{Months: 1}}}}, nil},
+		{TimeCycle, "R3/PT1H", TimerDefinition{Type: TimeCycle, Cycle: RepeatingInterval{3, Interval{Period: Components{Hours: 1}}}}, nil},
+		{TimeCycle, "R/2019-10-01T12:00:00Z/P1D", TimerDefinition{Type: TimeCycle, Cycle: RepeatingInterval{-1, Interval{
+			Start:  time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC),
+			End:    time.Date(2019, 10, 2, 12, 0, 0, 0, time.UTC),
+			Period: Components{Days: 1},
+		}}}, nil},
+
+		{TimeDate, "2019-10-01T12:00:00Z[Mars/Olympus]", TimerDefinition{}, ErrBadFormat},
+		{TimeDate, "PT10M", TimerDefinition{}, ErrBadFormat},
+		{TimeDuration, "PT0S", TimerDefinition{}, ErrRange},
+		{TimeDuration, "-PT10M", TimerDefinition{}, ErrBadFormat},
+		{TimeDuration, "R3/PT1H", TimerDefinition{}, ErrBadFormat},
+		{TimeCycle, "PT1H", TimerDefinition{}, ErrBadFormat},
+		{TimeCycle, "R/PT0S", TimerDefinition{}, ErrRange},
+		{TimeCycle, "R/2019-10-01/2019-10-01", TimerDefinition{}, ErrRange},
+		{TimeCycle, "0 0 9-17 * * MON-FRI", TimerDefinition{}, ErrBadFormat},
+		{TimerType(3), "PT1H", TimerDefinition{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		def, err := ParseTimer(vec.typ, vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, def, vec.in)
+	}
+}
+
+func TestTimerDefinitionNextFire(t *testing.T) {
+	t.Parallel()
+
+	date := func(d, h, m int) time.Time {
+		return time.Date(2019, 10, d, h, m, 0, 0, time.UTC)
+	}
+	activated := date(1, 9, 30)
+	vecs := []struct {
+		typ   TimerType
+		in    string
+		after time.Time
+		out   time.Time
+	}{
+		{TimeDate, "2019-10-01T12:00:00Z", time.Time{}, date(1, 12, 0)},
+		{TimeDate, "2019-10-01T12:00:00Z", date(1, 12, 0), time.Time{}},
+		{TimeDuration, "PT10M", time.Time{}, date(1, 9, 40)},
+		{TimeDuration, "PT10M", date(1, 9, 40), time.Time{}},
+		{TimeCycle, "R3/PT1H", time.Time{}, date(1, 10, 30)},
+		{TimeCycle, "R3/PT1H", date(1, 10, 30), date(1, 11, 30)},
+		{TimeCycle, "R3/PT1H", date(1, 11, 45), date(1, 12, 30)},
+		{TimeCycle, "R3/PT1H", date(1, 12, 30), time.Time{}},
+		{TimeCycle, "R/P1D", date(20, 0, 0), date(20, 9, 30)},
+		{TimeCycle, "R/2019-10-01T12:00:00Z/P1D", time.Time{}, date(1, 12, 0)},
+		{TimeCycle, "R/2019-10-01T12:00:00Z/P1D", date(5, 13, 0), date(6, 12, 0)},
+	}
+
+	for _, vec := range vecs {
+		def, err := ParseTimer(vec.typ, vec.in)
+		require.NoError(t, err, vec.in)
+
+		next, ok := def.NextFire(activated, vec.after)
+		assert.Equal(t, !vec.out.IsZero(), ok, "%s after %v", vec.in, vec.after)
+		assert.True(t, vec.out.Equal(next), "%s after %v: %v", vec.in, vec.after, next)
+	}
+}
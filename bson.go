@@ -0,0 +1,74 @@
+package duration
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// BSON element types used by MarshalBSONValue and UnmarshalBSONValue.
+const (
+	bsonDouble = 0x01
+	bsonString = 0x02
+	bsonNull   = 0x0a
+	bsonInt32  = 0x10
+	bsonInt64  = 0x12
+)
+
+// MarshalBSONValue implements the ValueMarshaler interface of
+// go.mongodb.org/mongo-driver/v2/bson, storing d as an ISO8601 string.
+func (d Duration) MarshalBSONValue() (byte, []byte, error) {
+	b, err := signedFormat.append(make([]byte, 4, 32), time.Duration(d))
+	if err != nil {
+		return 0, nil, err
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-4))
+	return bsonString, b, nil
+}
+
+// UnmarshalBSONValue implements the ValueUnmarshaler interface of
+// go.mongodb.org/mongo-driver/v2/bson. It accepts an ISO8601 string, or an
+// integer or double number of milliseconds as stored by other applications.
+// A BSON null leaves d unchanged.
+func (d *Duration) UnmarshalBSONValue(typ byte, b []byte) error {
+	switch typ {
+	case bsonNull:
+		return nil
+	case bsonString:
+		if len(b) < 5 || int(binary.LittleEndian.Uint32(b)) != len(b)-4 || b[len(b)-1] != 0 {
+			return ErrBadFormat
+		}
+		v, err := parseSigned(string(b[4 : len(b)-1]))
+		if err != nil {
+			return err
+		}
+		*d = Duration(v)
+	case bsonInt32:
+		if len(b) != 4 {
+			return ErrBadFormat
+		}
+		*d = Duration(int32(binary.LittleEndian.Uint32(b))) * Duration(time.Millisecond)
+	case bsonInt64:
+		if len(b) != 8 {
+			return ErrBadFormat
+		}
+		ms := int64(binary.LittleEndian.Uint64(b))
+		if ms > math.MaxInt64/int64(time.Millisecond) || ms < math.MinInt64/int64(time.Millisecond) {
+			return ErrRange
+		}
+		*d = Duration(ms) * Duration(time.Millisecond)
+	case bsonDouble:
+		if len(b) != 8 {
+			return ErrBadFormat
+		}
+		f := math.Round(math.Float64frombits(binary.LittleEndian.Uint64(b)) * float64(time.Millisecond))
+		if f >= math.MaxInt64 || f < math.MinInt64 || math.IsNaN(f) {
+			return ErrRange
+		}
+		*d = Duration(f)
+	default:
+		return ErrBadFormat
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package duration
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationMarshalBSONValue(t *testing.T) {
+	t.Parallel()
+
+	typ, b, err := Duration(90 * time.Minute).MarshalBSONValue()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x02), typ)
+	assert.Equal(t, append([]byte{8, 0, 0, 0}, "PT1H30M\x00"...), b)
+
+	_, _, err = Duration(math.MinInt64).MarshalBSONValue()
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestDurationUnmarshalBSONValue(t *testing.T) {
+	t.Parallel()
+
+	le32 := func(v uint32) []byte { return binary.LittleEndian.AppendUint32(nil, v) }
+	le64 := func(v uint64) []byte { return binary.LittleEndian.AppendUint64(nil, v) }
+
+	vecs := []struct {
+		typ byte
+		in  []byte
+		out Duration
+	}{
+		{0x02, append(le32(8), "PT1H30M\x00"...), Duration(90 * time.Minute)},
+		{0x02, append(le32(6), "-PT1S\x00"...), Duration(-time.Second)},
+		{0x12, le64(5400000), Duration(90 * time.Minute)},
+		{0x10, le32(1500), Duration(1500 * time.Millisecond)},
+		{0x10, le32(math.MaxUint32), Duration(-time.Millisecond)},
+		{0x01, le64(math.Float64bits(0.5)), Duration(500 * time.Microsecond)},
+		{0x0a, nil, Duration(time.Minute)},
+	}
+
+	for _, vec := range vecs {
+		d := Duration(time.Minute)
+		assert.NoError(t, d.UnmarshalBSONValue(vec.typ, vec.in), vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	typ, b, err := Duration(-dayTime).MarshalBSONValue()
+	assert.NoError(t, err)
+	var d Duration
+	assert.NoError(t, d.UnmarshalBSONValue(typ, b))
+	assert.Equal(t, Duration(-dayTime), d)
+
+	bad := []struct {
+		typ byte
+		in  []byte
+		err error
+	}{
+		{0x02, append(le32(9), "PT1H30M\x00"...), ErrBadFormat},
+		{0x02, append(le32(8), "PT1H30MX"...), ErrBadFormat},
+		{0x02, append(le32(3), "1h\x00"...), ErrBadFormat},
+		{0x02, nil, ErrBadFormat},
+		{0x12, le32(1), ErrBadFormat},
+		{0x12, le64(math.MaxInt64), ErrRange},
+		{0x01, le64(math.Float64bits(math.NaN())), ErrRange},
+		{0x08, []byte{1}, ErrBadFormat},
+	}
+
+	for _, vec := range bad {
+		var d Duration
+		assert.ErrorIs(t, d.UnmarshalBSONValue(vec.typ, vec.in), vec.err, vec.in)
+	}
+}
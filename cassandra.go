@@ -0,0 +1,21 @@
+package duration
+
+// CassandraDuration converts c to the months, days and nanoseconds of a CQL
+// duration value, in a MonthDayNano that converts directly to gocql.Duration
+// (e.g. gocql.Duration(v)). Conversion is as by MonthDayNano, except that
+// Cassandra requires the three counts to share a sign: when they do not,
+// ErrBadFormat is returned. Use MonthDayNano.Components to convert values
+// read from Cassandra.
+func (c Components) CassandraDuration() (MonthDayNano, error) {
+	v, err := c.MonthDayNano()
+	if err != nil {
+		return MonthDayNano{}, err
+	}
+
+	pos := v.Months > 0 || v.Days > 0 || v.Nanoseconds > 0
+	neg := v.Months < 0 || v.Days < 0 || v.Nanoseconds < 0
+	if pos && neg {
+		return MonthDayNano{}, ErrBadFormat
+	}
+	return v, nil
+}
@@ -0,0 +1,32 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentsCassandraDuration(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out MonthDayNano
+		err error
+	}{
+		{Components{Years: 1, Days: 2, Seconds: 3}, MonthDayNano{12, 2, 3000000000}, nil},
+		{Components{Months: -1, Days: -2}, MonthDayNano{-1, -2, 0}, nil},
+		{Components{Hours: 1, Minutes: -30}, MonthDayNano{0, 0, 1800000000000}, nil},
+		{Components{}, MonthDayNano{}, nil},
+		{Components{Months: 1, Days: -1}, MonthDayNano{}, ErrBadFormat},
+		{Components{Days: -1, Hours: 1}, MonthDayNano{}, ErrBadFormat},
+		{Components{Days: 0.5}, MonthDayNano{}, ErrBadFormat},
+		{Components{Months: 1 << 31}, MonthDayNano{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		v, err := vec.in.CassandraDuration()
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.in)
+		assert.Equal(t, vec.out, v, "%+v", vec.in)
+	}
+}
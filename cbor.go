@@ -0,0 +1,200 @@
+package duration
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// CBOR major types and simple values used by the CBOR methods.
+const (
+	cborUint    = 0 << 5
+	cborNegInt  = 1 << 5
+	cborText    = 3 << 5
+	cborSimple  = 7 << 5
+	cborNull    = cborSimple | 22
+	cborFloat16 = cborSimple | 25
+	cborFloat32 = cborSimple | 26
+	cborFloat64 = cborSimple | 27
+)
+
+// MarshalCBOR implements the Marshaler interface of github.com/fxamacker/cbor,
+// encoding d as an ISO8601 text string. Use Seconds for a numeric encoding.
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	var buf [32]byte
+	s, err := signedFormat.append(buf[:0], time.Duration(d))
+	if err != nil {
+		return nil, err
+	}
+	b := appendCBORHead(make([]byte, 0, len(s)+1), cborText, uint64(len(s)))
+	return append(b, s...), nil
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface of
+// github.com/fxamacker/cbor. It accepts an ISO8601 text string or a number of
+// seconds, as written by Duration and Seconds respectively. A CBOR null leaves
+// d unchanged.
+func (d *Duration) UnmarshalCBOR(b []byte) error {
+	if len(b) == 0 {
+		return ErrBadFormat
+	}
+	if b[0]&0xe0 != cborText {
+		return (*Seconds)(d).UnmarshalCBOR(b)
+	}
+
+	n, b, err := readCBORHead(b)
+	if err != nil {
+		return err
+	}
+	if n != uint64(len(b)) {
+		return ErrBadFormat
+	}
+	v, err := parseSigned(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Seconds is a Duration that encodes as a number of seconds in CBOR, for
+// payloads where a number is preferred to an ISO8601 string. Whole seconds
+// are written as an integer and other durations as a float.
+type Seconds Duration
+
+// MarshalCBOR implements the Marshaler interface of github.com/fxamacker/cbor.
+func (s Seconds) MarshalCBOR() ([]byte, error) {
+	d := time.Duration(s)
+	switch {
+	case d%time.Second != 0:
+		b := []byte{cborFloat64}
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(d.Seconds())), nil
+	case d < 0:
+		return appendCBORHead(nil, cborNegInt, uint64(-(d/time.Second)-1)), nil
+	}
+	return appendCBORHead(nil, cborUint, uint64(d/time.Second)), nil
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface of
+// github.com/fxamacker/cbor. It accepts an integer or float number of
+// seconds. A CBOR null leaves s unchanged.
+func (s *Seconds) UnmarshalCBOR(b []byte) error {
+	if len(b) == 0 {
+		return ErrBadFormat
+	}
+
+	var f float64
+	switch b[0] {
+	case cborNull:
+		if len(b) != 1 {
+			return ErrBadFormat
+		}
+		return nil
+	case cborFloat16:
+		if len(b) != 3 {
+			return ErrBadFormat
+		}
+		f = float16(binary.BigEndian.Uint16(b[1:]))
+	case cborFloat32:
+		if len(b) != 5 {
+			return ErrBadFormat
+		}
+		f = float64(math.Float32frombits(binary.BigEndian.Uint32(b[1:])))
+	case cborFloat64:
+		if len(b) != 9 {
+			return ErrBadFormat
+		}
+		f = math.Float64frombits(binary.BigEndian.Uint64(b[1:]))
+	default:
+		major := b[0] & 0xe0
+		if major != cborUint && major != cborNegInt {
+			return ErrBadFormat
+		}
+		n, rest, err := readCBORHead(b)
+		if err != nil {
+			return err
+		}
+		if len(rest) != 0 {
+			return ErrBadFormat
+		}
+		if n > uint64(math.MaxInt64/time.Second) {
+			return ErrRange
+		}
+		v := time.Duration(n) * time.Second
+		if major == cborNegInt {
+			v = -v - time.Second
+		}
+		*s = Seconds(v)
+		return nil
+	}
+
+	v, err := fromSeconds(f)
+	if err != nil {
+		return err
+	}
+	*s = Seconds(v)
+	return nil
+}
+
+// appendCBORHead appends the initial bytes of a CBOR data item of the given
+// major type and argument n.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// readCBORHead reads the argument of the CBOR data item at the start of b,
+// returning it and the rest of b. Indefinite lengths are not supported.
+func readCBORHead(b []byte) (uint64, []byte, error) {
+	info := b[0] & 0x1f
+	b = b[1:]
+
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info <= 27:
+		size = 1 << (info - 24)
+	default:
+		return 0, nil, ErrBadFormat
+	}
+	if len(b) < size {
+		return 0, nil, ErrBadFormat
+	}
+
+	var n uint64
+	for _, c := range b[:size] {
+		n = n<<8 | uint64(c)
+	}
+	return n, b[size:], nil
+}
+
+// float16 converts an IEEE 754 half-precision value to a float64.
+func float16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h >> 10 & 0x1f)
+	frac := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+	return sign * math.Ldexp(frac+1024, exp-25)
+}
@@ -0,0 +1,128 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationCBOR(t *testing.T) {
+	t.Parallel()
+
+	b, err := Duration(90 * time.Minute).MarshalCBOR()
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0x67}, "PT1H30M"...), b)
+
+	_, err = Duration(math.MinInt64).MarshalCBOR()
+	assert.ErrorIs(t, err, ErrRange)
+
+	vecs := []struct {
+		in  []byte
+		out Duration
+	}{
+		{append([]byte{0x67}, "PT1H30M"...), Duration(90 * time.Minute)},
+		{append([]byte{0x78, 5}, "-PT1S"...), Duration(-time.Second)},
+		{[]byte{0x19, 0x15, 0x18}, Duration(90 * time.Minute)},
+		{[]byte{0x20}, Duration(-time.Second)},
+		{[]byte{0xf9, 0x3e, 0x00}, Duration(1500 * time.Millisecond)},
+		{[]byte{0xfa, 0x3f, 0x00, 0x00, 0x00}, Duration(500 * time.Millisecond)},
+		{[]byte{0xfb, 0x3f, 0xd0, 0, 0, 0, 0, 0, 0}, Duration(250 * time.Millisecond)},
+		{[]byte{0xf6}, Duration(time.Minute)},
+	}
+
+	for _, vec := range vecs {
+		d := Duration(time.Minute)
+		assert.NoError(t, d.UnmarshalCBOR(vec.in), vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  []byte
+		err error
+	}{
+		{nil, ErrBadFormat},
+		{[]byte{0x68, 'P'}, ErrBadFormat},
+		{append([]byte{0x62}, "1h"...), ErrBadFormat},
+		{[]byte{0x7f}, ErrBadFormat},
+		{[]byte{0x19, 0x15}, ErrBadFormat},
+		{[]byte{0x01, 0x02}, ErrBadFormat},
+		{[]byte{0xa0}, ErrBadFormat},
+		{[]byte{0xf5}, ErrBadFormat},
+		{[]byte{0x1b, 0xff, 0, 0, 0, 0, 0, 0, 0}, ErrRange},
+		{[]byte{0xf9, 0x7e, 0x00}, ErrRange},
+	}
+
+	for _, vec := range bad {
+		var d Duration
+		assert.ErrorIs(t, d.UnmarshalCBOR(vec.in), vec.err, vec.in)
+	}
+}
+
+func TestSecondsCBOR(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Seconds
+		out []byte
+	}{
+		{0, []byte{0x00}},
+		{Seconds(10 * time.Second), []byte{0x0a}},
+		{Seconds(90 * time.Minute), []byte{0x19, 0x15, 0x18}},
+		{Seconds(-time.Second), []byte{0x20}},
+		{Seconds(-30 * time.Second), []byte{0x38, 29}},
+		{Seconds(1500 * time.Millisecond), []byte{0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, vec := range vecs {
+		b, err := vec.in.MarshalCBOR()
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, b, vec.in)
+
+		var s Seconds
+		assert.NoError(t, s.UnmarshalCBOR(b), vec.in)
+		assert.Equal(t, vec.in, s)
+	}
+
+	var s Seconds
+	assert.ErrorIs(t, s.UnmarshalCBOR(append([]byte{0x64}, "PT1S"...)), ErrBadFormat)
+}
+
+func TestCBORHead(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		n   uint64
+		out []byte
+	}{
+		{0, []byte{0x60}},
+		{23, []byte{0x77}},
+		{24, []byte{0x78, 24}},
+		{256, []byte{0x79, 1, 0}},
+		{1 << 16, []byte{0x7a, 0, 1, 0, 0}},
+		{1 << 32, []byte{0x7b, 0, 0, 0, 1, 0, 0, 0, 0}},
+	}
+
+	for _, vec := range vecs {
+		b := appendCBORHead(nil, cborText, vec.n)
+		assert.Equal(t, vec.out, b, vec.n)
+
+		n, rest, err := readCBORHead(b)
+		assert.NoError(t, err, vec.n)
+		assert.Equal(t, vec.n, n)
+		assert.Empty(t, rest)
+	}
+}
+
+func TestFloat16(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, float16(0x0000))
+	assert.Equal(t, 1.0, float16(0x3c00))
+	assert.Equal(t, -2.0, float16(0xc000))
+	assert.Equal(t, 65504.0, float16(0x7bff))
+	assert.Equal(t, 5.960464477539063e-08, float16(0x0001))
+	assert.True(t, math.IsInf(float16(0x7c00), 1))
+	assert.True(t, math.IsNaN(float16(0x7e00)))
+}
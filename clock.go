@@ -0,0 +1,107 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatClock returns d in the clock-style form "HH:MM:SS", with a ".fff"
+// millisecond fraction when d is not a whole number of seconds (e.g.
+// "01:30:05.500"). d is rounded to the nearest millisecond. Hours are not
+// wrapped at 24 and are written with more digits as needed; negative durations
+// have a leading "-".
+func FormatClock(d time.Duration) string {
+	var buf [32]byte
+	b := buf[:0]
+
+	d = d.Round(time.Millisecond)
+	n := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		n = uint64(-d)
+	}
+
+	ms := n / uint64(time.Millisecond)
+	b = appendPadded(b, int64(ms/uint64(time.Hour/time.Millisecond)), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(ms/60000%60), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(ms/1000%60), 2)
+	if ms%1000 != 0 {
+		b = append(b, '.')
+		b = appendPadded(b, int64(ms%1000), 3)
+	}
+	return string(b)
+}
+
+// ParseClock parses a clock-style duration, the form written by FormatClock:
+// "HH:MM:SS" or "MM:SS", with an optional decimal fraction of a second using
+// "." or "," (e.g. "01:30:05.5") and an optional leading "-". The leading
+// field may have any number of digits; the fields after it must have two
+// digits and be less than 60, otherwise ErrRange is returned. Surrounding white
+// space is ignored. Use Format to convert the result to ISO8601.
+func ParseClock(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+
+	var frac string
+	if i := strings.IndexAny(s, ".,"); i != -1 {
+		s, frac = s[:i], s[i+1:]
+		if frac == "" || skipDigits(frac, 0) != len(frac) {
+			return 0, ErrBadFormat
+		}
+	}
+
+	fields := strings.Split(s, ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, ErrBadFormat
+	}
+
+	var d time.Duration
+	for i, f := range fields {
+		if f == "" || skipDigits(f, 0) != len(f) {
+			return 0, ErrBadFormat
+		}
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, ErrRange
+		}
+		if i > 0 {
+			if len(f) != 2 {
+				return 0, ErrBadFormat
+			}
+			if n >= 60 {
+				return 0, ErrRange
+			}
+		}
+
+		t := time.Second
+		if len(fields)-i == 2 {
+			t = time.Minute
+		} else if len(fields)-i == 3 {
+			t = time.Hour
+		}
+		if n > int64((math.MaxInt64-d)/t) {
+			return 0, ErrRange
+		}
+		d += time.Duration(n) * t
+	}
+
+	for i, t := 0, 100*time.Millisecond; i < len(frac) && t > 0; i, t = i+1, t/10 {
+		d += time.Duration(frac[i]-'0') * t
+		if d < 0 {
+			return 0, ErrRange
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,95 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatClock(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{0, "00:00:00"},
+		{90*time.Minute + 5*time.Second, "01:30:05"},
+		{90*time.Minute + 5500*time.Millisecond, "01:30:05.500"},
+		{time.Millisecond, "00:00:00.001"},
+		{1499 * time.Microsecond, "00:00:00.001"},
+		{999500 * time.Microsecond, "00:00:01"},
+		{36 * time.Hour, "36:00:00"},
+		{150 * time.Hour, "150:00:00"},
+		{-90 * time.Second, "-00:01:30"},
+		{-400 * time.Microsecond, "00:00:00"},
+		{time.Duration(math.MinInt64), "-2562047:47:16.854"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, FormatClock(vec.in), vec.in)
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"01:30:05.5", 90*time.Minute + 5500*time.Millisecond},
+		{"01:30:05,5", 90*time.Minute + 5500*time.Millisecond},
+		{"1:30:05", 90*time.Minute + 5*time.Second},
+		{"36:00:00", 36 * time.Hour},
+		{"150:00:00", 150 * time.Hour},
+		{"30:05", 30*time.Minute + 5*time.Second},
+		{"5:30", 5*time.Minute + 30*time.Second},
+		{"90:00", 90 * time.Minute},
+		{"00:00:00.000000001", time.Nanosecond},
+		{"00:00:00.0000000019", time.Nanosecond},
+		{"-00:01:30", -90 * time.Second},
+		{" 00:00:01\n", time.Second},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseClock(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"01", ErrBadFormat},
+		{"1:2:3:4", ErrBadFormat},
+		{"01:5:00", ErrBadFormat},
+		{"01::00", ErrBadFormat},
+		{"01:00:00.", ErrBadFormat},
+		{"01:00:00.5.5", ErrBadFormat},
+		{"+01:00:00", ErrBadFormat},
+		{"PT1H", ErrBadFormat},
+		{"01:60:00", ErrRange},
+		{"01:00:60", ErrRange},
+		{"3000000:00:00", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseClock(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestClockRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"00:00:00", "01:30:05.500", "36:00:00", "-00:01:30.001"} {
+		d, err := ParseClock(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, in, FormatClock(d))
+	}
+}
@@ -0,0 +1,237 @@
+package duration
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Components holds the individual elements of an ISO8601 duration exactly as
+// written, without converting them to a time.Duration. Unlike Parse, it can
+// represent month elements and values such as "PT90M" that Format would
+// rebalance.
+type Components struct {
+	Years   float64
+	Months  float64
+	Weeks   float64
+	Days    float64
+	Hours   float64
+	Minutes float64
+	Seconds float64
+}
+
+// ParseComponents parses an ISO8601-formatted duration value into its
+// elements. Month elements are accepted; all other rules are those of
+// ParseWithOptions.
+func ParseComponents(s string, opts ...ParseOption) (Components, error) {
+	c := newParseConfig(opts)
+	c.months = true
+
+	elems, err := c.elements(c.trim(s))
+	if err != nil {
+		return Components{}, err
+	}
+
+	var comp Components
+	for _, e := range elems {
+		*comp.field(e.unit) += e.value()
+	}
+	return comp, nil
+}
+
+// FormatComponents returns the ISO8601 representation of c, writing each
+// non-zero field as stored with no rebalancing between elements (e.g. "PT90M"
+// for Minutes=90). Fractions are written in the shortest form that represents
+// the field exactly. Of the format options, only AllowNegative,
+// DecimalSeparator, JavaCompatible and ZeroAs apply. With AllowNegative, fields
+// that are all negative or zero are written with a leading "-"; with
+// JavaCompatible, each field is written with its own sign. Otherwise negative
+// fields are not supported, and non-finite fields never are.
+func FormatComponents(c Components, opts ...FormatOption) (string, error) {
+	fc := newFormatConfig(opts)
+
+	var s bytes.Buffer
+	if fc.negative && c.negative() {
+		c = c.abs()
+		s.WriteByte('-')
+	}
+	s.WriteByte('P')
+
+	var inTime bool
+	for u := Year; u < numUnits; u++ {
+		v := *c.field(u)
+		switch {
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			return "", ErrBadFormat
+		case v < 0 && !fc.java:
+			return "", ErrNoNegative
+		case v == 0:
+			continue
+		}
+
+		if u.isTime() && !inTime {
+			s.WriteByte('T')
+			inTime = true
+		}
+
+		b := strconv.AppendFloat(s.AvailableBuffer(), v, 'f', -1, 64)
+		if i := bytes.IndexByte(b, '.'); i != -1 {
+			b[i] = fc.separator()
+		}
+		s.Write(b)
+		s.WriteByte(u.Designator())
+	}
+
+	if s.Len() == 1 {
+		if fc.zero != "" {
+			return string(fc.zero), nil
+		}
+		s.WriteString("0Y")
+	}
+	return s.String(), nil
+}
+
+// String returns the ISO8601 representation of c as written by
+// FormatComponents with AllowNegative (e.g. "P1Y2M3D"), so that Components can
+// be used directly as a calendar period with fmt. It returns "" for components
+// that FormatComponents rejects.
+func (c Components) String() string {
+	s, _ := FormatComponents(c, AllowNegative())
+	return s
+}
+
+// Split breaks d into years, days, hours, minutes and seconds using the same
+// nominal lengths as Format, with any fraction of a second in Seconds. The
+// fields of a negative duration are all negative or zero.
+func Split(d time.Duration) Components {
+	var c Components
+	for _, u := range formatUnits[:len(formatUnits)-1] {
+		t := unitTimes[u]
+		*c.field(u) = float64(d / t)
+		d %= t
+	}
+	c.Seconds = d.Seconds()
+	return c
+}
+
+// Duration converts c to a time.Duration using the same nominal lengths as
+// Parse. It fails with ErrNoMonth if c has a month element, ErrBadFormat if a
+// field is not finite, and ErrRange if c is too long for a time.Duration.
+func (c Components) Duration() (time.Duration, error) {
+	if c.Months != 0 {
+		return 0, ErrNoMonth
+	}
+
+	var d time.Duration
+	for u := Year; u < numUnits; u++ {
+		v := *c.field(u)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, ErrBadFormat
+		}
+		ns := math.Round(v * float64(unitTimes[u]))
+		if ns >= math.MaxInt64 || ns < math.MinInt64 {
+			return 0, ErrRange
+		}
+		n := time.Duration(ns)
+		if n > 0 && d > math.MaxInt64-n || n < 0 && d < math.MinInt64-n {
+			return 0, ErrRange
+		}
+		d += n
+	}
+	return d, nil
+}
+
+// AddTo returns t plus c with calendar arithmetic in the location of t, as
+// JavaScript's Temporal does: years and months are added first, keeping the
+// day of the month unless the month is shorter, in which case its last day is
+// used (e.g. 2007-03-31 plus P1M is 2007-04-30); then weeks and days, keeping
+// the wall-clock time across daylight saving time changes; then hours,
+// minutes and seconds as exact lengths. Fields may be negative. Years and
+// months must come to a whole number of months and fractions of a day are
+// exact multiples of 24 hours; non-finite fields or a fraction of a month fail
+// with ErrBadFormat, and values too large to add with ErrRange.
+func (c Components) AddTo(t time.Time) (time.Time, error) {
+	for u := Year; u < numUnits; u++ {
+		if v := *c.field(u); math.IsNaN(v) || math.IsInf(v, 0) {
+			return time.Time{}, ErrBadFormat
+		}
+	}
+
+	months := c.Years*12 + c.Months
+	days, frac := math.Modf(c.Weeks*7 + c.Days)
+	switch {
+	case months != math.Trunc(months):
+		return time.Time{}, ErrBadFormat
+	case math.Abs(months) > math.MaxInt32 || math.Abs(days) > math.MaxInt32:
+		return time.Time{}, ErrRange
+	}
+
+	ns := frac * float64(dayTime)
+	for u := Hour; u < numUnits; u++ {
+		ns += *c.field(u) * float64(unitTimes[u])
+	}
+	ns = math.Round(ns)
+	if ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return time.Time{}, ErrRange
+	}
+
+	if months != 0 {
+		y, m, d := t.Date()
+		m += time.Month(months)
+		last := time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		hh, mm, ss := t.Clock()
+		t = time.Date(y, m, min(d, last), hh, mm, ss, t.Nanosecond(), t.Location())
+	}
+	return t.AddDate(0, 0, int(days)).Add(time.Duration(ns)), nil
+}
+
+// negative reports whether c has a negative field and no positive ones.
+func (c Components) negative() bool {
+	var neg bool
+	for u := Year; u < numUnits; u++ {
+		if v := *c.field(u); v > 0 {
+			return false
+		} else if v < 0 {
+			neg = true
+		}
+	}
+	return neg
+}
+
+func (c Components) scale(f float64) Components {
+	for u := Year; u < numUnits; u++ {
+		*c.field(u) *= f
+	}
+	return c
+}
+
+func (c Components) neg() Components {
+	return c.scale(-1)
+}
+
+func (c Components) abs() Components {
+	for u := Year; u < numUnits; u++ {
+		p := c.field(u)
+		*p = math.Abs(*p)
+	}
+	return c
+}
+
+func (c *Components) field(u Unit) *float64 {
+	switch u {
+	case Year:
+		return &c.Years
+	case Month:
+		return &c.Months
+	case Week:
+		return &c.Weeks
+	case Day:
+		return &c.Days
+	case Hour:
+		return &c.Hours
+	case Minute:
+		return &c.Minutes
+	}
+	return &c.Seconds
+}
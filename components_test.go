@@ -0,0 +1,203 @@
+package duration
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseComponents(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Components
+	}{
+		{"PT90M", Components{Minutes: 90}},
+		{"P1Y2M3DT4H5M6.5S", Components{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}},
+		{"P3W", Components{Weeks: 3}},
+		{"P1M", Components{Months: 1}},
+		{"PT36H", Components{Hours: 36}},
+		{"PT0,25S", Components{Seconds: 0.25}},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParseComponents(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, c, vec.in)
+	}
+
+	c, err := ParseComponents("PT30M30M", AllowRepeated())
+	assert.NoError(t, err)
+	assert.Equal(t, Components{Minutes: 60}, c)
+
+	c, err = ParseComponents("-P1M2D", AllowNegative())
+	assert.NoError(t, err)
+	assert.Equal(t, Components{Months: -1, Days: -2}, c)
+
+	_, err = ParseComponents("P1M1W")
+	assert.ErrorIs(t, err, ErrBadFormat)
+	_, err = ParseComponents("PT1H1H")
+	assert.ErrorIs(t, err, ErrDuplicate)
+}
+
+func TestFormatComponents(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   Components
+		opts []FormatOption
+		out  string
+	}{
+		{Components{Minutes: 90}, nil, "PT90M"},
+		{Components{Hours: 36}, nil, "PT36H"},
+		{Components{Years: 1, Months: 2, Days: 3}, nil, "P1Y2M3D"},
+		{Components{Weeks: 2}, nil, "P2W"},
+		{Components{Days: 1, Seconds: 0.001}, nil, "P1DT0.001S"},
+		{Components{Seconds: 1.5}, []FormatOption{DecimalSeparator(SeparatorComma)}, "PT1,5S"},
+		{Components{}, nil, "P0Y"},
+		{Components{}, []FormatOption{ZeroAs(ZeroSeconds)}, "PT0S"},
+		{Components{Months: -1, Days: -2}, []FormatOption{AllowNegative()}, "-P1M2D"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatComponents(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	_, err := FormatComponents(Components{Hours: -1})
+	assert.ErrorIs(t, err, ErrNoNegative)
+	_, err = FormatComponents(Components{Hours: -1, Minutes: 30}, AllowNegative())
+	assert.ErrorIs(t, err, ErrNoNegative)
+	_, err = FormatComponents(Components{Hours: math.NaN()})
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestComponentsString(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out string
+	}{
+		{Components{Years: 1, Months: 2, Days: 3}, "P1Y2M3D"},
+		{Components{Years: 1, Months: 14}, "P1Y14M"},
+		{Components{Months: 1, Weeks: 2}, "P1M2W"},
+		{Components{Months: -6}, "-P6M"},
+		{Components{Days: 45, Hours: 36}, "P45DT36H"},
+		{Components{}, "P0Y"},
+		{Components{Months: 1, Days: -1}, ""},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, vec.in.String(), vec.in)
+	}
+	assert.Equal(t, "P1Y2M", fmt.Sprint(Components{Years: 1, Months: 2}))
+}
+
+func TestComponentsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"PT90M", "P1Y2M3DT4H5M6.5S", "P10W", "PT0.000001S", "P1DT24H"} {
+		c, err := ParseComponents(in)
+		assert.NoError(t, err, in)
+
+		s, err := FormatComponents(c)
+		assert.NoError(t, err, in)
+		assert.Equal(t, in, s)
+	}
+}
+
+func TestComponentsDuration(t *testing.T) {
+	t.Parallel()
+
+	d, err := Components{Hours: 1, Minutes: 90, Seconds: 0.3}.Duration()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour+30*time.Minute+300*time.Millisecond, d)
+
+	d, err = Components{Years: 1, Weeks: 1}.Duration()
+	assert.NoError(t, err)
+	assert.Equal(t, yearTime+weekTime, d)
+
+	_, err = Components{Months: 1}.Duration()
+	assert.ErrorIs(t, err, ErrNoMonth)
+
+	d, err = Components{Years: -1, Days: 1}.Duration()
+	assert.NoError(t, err)
+	assert.Equal(t, dayTime-yearTime, d)
+
+	vecs := []struct {
+		in  Components
+		err error
+	}{
+		{Components{Years: 1000}, ErrRange},
+		{Components{Years: -1000}, ErrRange},
+		{Components{Seconds: 9223372036.854}, nil},
+		{Components{Seconds: 9223372037}, ErrRange},
+		{Components{Years: 292, Days: 200}, ErrRange},
+		{Components{Years: -292, Days: -200}, ErrRange},
+		{Components{Hours: math.NaN()}, ErrBadFormat},
+		{Components{Days: math.Inf(1)}, ErrBadFormat},
+		{Components{Seconds: math.Inf(-1)}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		_, err := vec.in.Duration()
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestComponentsAddTo(t *testing.T) {
+	t.Parallel()
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	utc := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+	vecs := []struct {
+		c   Components
+		t   time.Time
+		out time.Time
+		err error
+	}{
+		{Components{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30}, utc(2007, 3, 1, 13, 0), utc(2008, 5, 11, 15, 30), nil},
+		{Components{Months: 1}, utc(2007, 3, 31, 0, 0), utc(2007, 4, 30, 0, 0), nil},
+		{Components{Months: 1}, utc(2008, 1, 31, 0, 0), utc(2008, 2, 29, 0, 0), nil},
+		{Components{Years: 1}, utc(2008, 2, 29, 0, 0), utc(2009, 2, 28, 0, 0), nil},
+		{Components{Years: -1, Months: -1}, utc(2009, 3, 31, 0, 0), utc(2008, 2, 29, 0, 0), nil},
+		{Components{Years: 0.5}, utc(2007, 1, 1, 0, 0), utc(2007, 7, 1, 0, 0), nil},
+		{Components{Weeks: 1, Days: 0.5}, utc(2007, 1, 1, 0, 0), utc(2007, 1, 8, 12, 0), nil},
+		{Components{Minutes: -90}, utc(2007, 1, 1, 0, 0), utc(2006, 12, 31, 22, 30), nil},
+		{Components{Days: 1}, time.Date(2007, 3, 10, 12, 0, 0, 0, ny), time.Date(2007, 3, 11, 12, 0, 0, 0, ny), nil},
+		{Components{Hours: 24}, time.Date(2007, 3, 10, 12, 0, 0, 0, ny), time.Date(2007, 3, 11, 13, 0, 0, 0, ny), nil},
+		{Components{}, utc(2007, 1, 1, 0, 0), utc(2007, 1, 1, 0, 0), nil},
+		{Components{Months: 0.5}, utc(2007, 1, 1, 0, 0), time.Time{}, ErrBadFormat},
+		{Components{Seconds: math.Inf(1)}, utc(2007, 1, 1, 0, 0), time.Time{}, ErrBadFormat},
+		{Components{Months: 1 << 31}, utc(2007, 1, 1, 0, 0), time.Time{}, ErrRange},
+		{Components{Hours: 1e10}, utc(2007, 1, 1, 0, 0), time.Time{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		out, err := vec.c.AddTo(vec.t)
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.c)
+		assert.True(t, vec.out.Equal(out), "%+v: %v", vec.c, out)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Components{Years: 1, Days: 10, Hours: 1, Minutes: 1, Seconds: 1.001},
+		Split(yearTime+10*dayTime+time.Hour+time.Minute+time.Second+time.Millisecond))
+	assert.Equal(t, Components{Hours: 1, Minutes: 30}, Split(90*time.Minute))
+	assert.Equal(t, Components{Hours: -1, Minutes: -30}, Split(-90*time.Minute))
+	assert.Equal(t, Components{}, Split(0))
+}
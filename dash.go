@@ -0,0 +1,86 @@
+package duration
+
+import "strings"
+
+// DASHWarning is a feature of a duration that is valid ISO8601 but that DASH
+// players are known to handle inconsistently when it appears in an MPD
+// attribute such as mediaPresentationDuration or minBufferTime.
+type DASHWarning int
+
+const (
+	// DASHCalendar marks year or month elements, whose length players
+	// disagree on.
+	DASHCalendar DASHWarning = iota + 1
+
+	// DASHWeeks marks week elements, which xs:duration does not include and
+	// which many MPD parsers reject.
+	DASHWeeks
+
+	// DASHFraction marks a fraction in an element other than seconds, which
+	// xs:duration does not allow.
+	DASHFraction
+
+	// DASHComma marks a "," decimal separator, which xs:duration does not
+	// allow.
+	DASHComma
+
+	// DASHPrecision marks more than three fractional digits of a second,
+	// beyond the millisecond precision most players keep; the rounding can
+	// shift segment boundaries.
+	DASHPrecision
+
+	// DASHNegative marks a negative duration, which no MPD attribute allows.
+	DASHNegative
+
+	// DASHZero marks a zero duration, which players read as unknown or
+	// reject in attributes such as mediaPresentationDuration.
+	DASHZero
+)
+
+var dashWarnings = [...]string{
+	DASHCalendar:  "year or month element",
+	DASHWeeks:     "week element",
+	DASHFraction:  "fraction outside seconds",
+	DASHComma:     "comma decimal separator",
+	DASHPrecision: "more than millisecond precision",
+	DASHNegative:  "negative duration",
+	DASHZero:      "zero duration",
+}
+
+// String returns a short description of w, e.g. "week element".
+func (w DASHWarning) String() string {
+	if w <= 0 || int(w) >= len(dashWarnings) {
+		return "unknown"
+	}
+	return dashWarnings[w]
+}
+
+// CheckDASH lints s, a duration destined for an MPEG-DASH manifest, returning
+// the features that players are known to handle inconsistently, in the order
+// declared. A nil result means that s is safe to use. Strings that are not
+// durations at all, as by ParseComponents with AllowNegative and MixedWeeks,
+// fail with the error from ParseComponents.
+func CheckDASH(s string) ([]DASHWarning, error) {
+	s = strings.TrimSpace(s)
+	c, err := ParseComponents(s, AllowNegative(), MixedWeeks())
+	if err != nil {
+		return nil, err
+	}
+
+	var ws []DASHWarning
+	add := func(w DASHWarning, ok bool) {
+		if ok {
+			ws = append(ws, w)
+		}
+	}
+
+	i := strings.IndexAny(s, ".,")
+	add(DASHCalendar, c.Years != 0 || c.Months != 0)
+	add(DASHWeeks, strings.Contains(s, "W"))
+	add(DASHFraction, i != -1 && !strings.HasSuffix(s, "S"))
+	add(DASHComma, i != -1 && s[i] == ',')
+	add(DASHPrecision, i != -1 && skipDigits(s, i+1)-i-1 > 3)
+	add(DASHNegative, strings.HasPrefix(s, "-"))
+	add(DASHZero, c == Components{})
+	return ws, nil
+}
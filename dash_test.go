@@ -0,0 +1,50 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDASH(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out []DASHWarning
+		err error
+	}{
+		{"PT0H9M56.46S", nil, nil},
+		{"PT634.566S", nil, nil},
+		{"P1DT2H", nil, nil},
+		{" PT2S ", nil, nil},
+		{"P1Y", []DASHWarning{DASHCalendar}, nil},
+		{"P1M", []DASHWarning{DASHCalendar}, nil},
+		{"P1W", []DASHWarning{DASHWeeks}, nil},
+		{"P1W2D", []DASHWarning{DASHWeeks}, nil},
+		{"PT1.5H", []DASHWarning{DASHFraction}, nil},
+		{"PT1,5S", []DASHWarning{DASHComma}, nil},
+		{"PT1.9200001S", []DASHWarning{DASHPrecision}, nil},
+		{"-PT1S", []DASHWarning{DASHNegative}, nil},
+		{"PT0S", []DASHWarning{DASHZero}, nil},
+		{"P0Y", []DASHWarning{DASHZero}, nil},
+		{"P1Y2M3W1,25D", []DASHWarning{DASHCalendar, DASHWeeks, DASHFraction, DASHComma}, nil},
+		{"", nil, ErrBadFormat},
+		{"1h", nil, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		ws, err := CheckDASH(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, ws, vec.in)
+	}
+}
+
+func TestDASHWarningString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "week element", DASHWeeks.String())
+	assert.Equal(t, "zero duration", DASHZero.String())
+	assert.Equal(t, "unknown", DASHWarning(0).String())
+	assert.Equal(t, "unknown", DASHWarning(100).String())
+}
@@ -3,10 +3,8 @@
 package duration
 
 import (
-	"bytes"
 	"errors"
-	"fmt"
-	"regexp"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -17,12 +15,23 @@ var (
 	ErrBadFormat = errors.New("bad format string")
 
 	// ErrNoMonth is returned when a month element is in the format string.
+	// Parsing functions return it wrapped in a *MonthError.
 	ErrNoMonth = errors.New("no month elements allowed")
 
+	// ErrNoYear is returned when a year element is in the format string and
+	// the RejectYears option is in effect.
+	ErrNoYear = errors.New("no year elements allowed")
+
+	// ErrDuplicate is returned when an element designator appears more than
+	// once in the format string.
+	ErrDuplicate = errors.New("duplicate element designator")
+
+	// ErrRange is returned when an element is too large for the requested
+	// representation.
+	ErrRange = errors.New("element out of range")
+
 	// ErrNoNegative is returned when a negative Duration is formatted.
 	ErrNoNegative = errors.New("cannot format negative duration")
-
-	format = regexp.MustCompile(`^P((?P<year>\d+((\.|,)\d+)?)Y)?((?P<month>\d+((\.|,)\d+)?)M)?((?P<week>\d+((\.|,)\d+)?)W)?((?P<day>\d+((\.|,)\d+)?)D)?(T((?P<hour>\d+((\.|,)\d+)?)H)?((?P<minute>\d+((\.|,)\d+)?)M)?((?P<second>\d+((\.|,)\d+)?)S)?)?$`)
 )
 
 const (
@@ -34,84 +43,148 @@ const (
 // Parse parses an ISO8601-formatted duration value and returns a time.Duration.
 // Month elements (e.g. "P1M") are not supported.
 func Parse(s string) (time.Duration, error) {
-	match := format.FindStringSubmatch(strings.TrimSpace(s))
-	if match == nil {
-		return 0, ErrBadFormat
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse but accepts options that adjust the grammar
+// it accepts. With no options it behaves exactly like Parse.
+func ParseWithOptions(s string, opts ...ParseOption) (time.Duration, error) {
+	elems, err := parseElements(s, opts)
+	if err != nil {
+		return 0, err
+	}
+	return sumDuration(elems), nil
+}
+
+// ParseSeconds is like Parse but returns the duration as a number of seconds.
+// The value is computed directly from the decimal elements of s, so fractions
+// finer than a nanosecond are preserved.
+func ParseSeconds(s string) (float64, error) {
+	elems, err := parseElements(s, nil)
+	if err != nil {
+		return 0, err
 	}
 
-	var d time.Duration
-	var numElems, weekElem, fracElem int
+	var secs float64
+	for _, e := range elems {
+		secs += e.seconds()
+	}
+	return secs, nil
+}
 
-	for i, name := range format.SubexpNames() {
-		part := match[i]
-		if i == 0 || name == "" || part == "" {
-			continue
-		}
+// ParseAs parses s and returns the result as the type chosen by the caller:
+// nanoseconds for int64, seconds (as from ParseSeconds) for float64, or a
+// time.Duration.
+func ParseAs[T int64 | float64 | time.Duration](s string) (T, error) {
+	var v T
+	var err error
+
+	switch p := any(&v).(type) {
+	case *float64:
+		*p, err = ParseSeconds(s)
+	case *int64:
+		var d time.Duration
+		d, err = Parse(s)
+		*p = int64(d)
+	case *time.Duration:
+		*p, err = Parse(s)
+	}
+	return v, err
+}
 
-		whole, frac, hasFrac, err := parseDecimal(part)
-		if err != nil {
-			return 0, ErrBadFormat
+// parseElements scans s and checks the resulting elements against the rules
+// of the ISO8601 grammar, as adjusted by opts.
+func parseElements(s string, opts []ParseOption) ([]element, error) {
+	c := newParseConfig(opts)
+	return c.elements(c.trim(s))
+}
+
+func (c *parseConfig) elements(s string) ([]element, error) {
+	elems, neg, err := scan(s, c.signed)
+	if err != nil {
+		return nil, err
+	}
+	if neg && !c.negative {
+		return nil, ErrBadFormat
+	}
+
+	// Decimal fractions must use the permitted separator
+	switch c.sep {
+	case SeparatorDot:
+		if strings.Contains(s, ",") {
+			return nil, ErrBadFormat
+		}
+	case SeparatorComma:
+		if strings.Contains(s, ".") {
+			return nil, ErrBadFormat
 		}
+	}
+
+	var seen [numUnits]bool
+	var distinct int
+	var weekElem, fracElem bool
+	last := Unit(-1)
 
+	for i, e := range elems {
 		// Fractional elements must be the last element in the string
-		if hasFrac {
-			if fracElem > 0 {
-				return 0, ErrBadFormat
-			}
-			fracElem = i
-		} else if fracElem > 0 {
-			return 0, ErrBadFormat
+		if fracElem {
+			return nil, ErrBadFormat
 		}
+		fracElem = e.hasFrac
 
-		switch name {
-		case "year":
-			d += time.Duration(whole) * yearTime
-			if frac != 0 {
-				d += time.Duration(frac * float64(yearTime))
-			}
-		case "month":
-			return 0, ErrNoMonth
-		case "week":
-			d += time.Duration(whole) * weekTime
-			if frac != 0 {
-				d += time.Duration(frac * float64(weekTime))
-			}
-			weekElem = i
-		case "day":
-			d += time.Duration(whole) * dayTime
-			if frac != 0 {
-				d += time.Duration(frac * float64(dayTime))
-			}
-		case "hour":
-			d += time.Duration(whole) * time.Hour
-			if frac != 0 {
-				d += time.Duration(frac * float64(time.Hour))
+		// Elements must appear at most once and, unless relaxed, in order
+		if seen[e.unit] {
+			if !c.repeated {
+				return nil, ErrDuplicate
 			}
-		case "minute":
-			d += time.Duration(whole) * time.Minute
-			if frac != 0 {
-				d += time.Duration(frac * float64(time.Minute))
+		} else {
+			seen[e.unit] = true
+			distinct++
+		}
+		if e.unit < last && !c.outOfOrder {
+			return nil, ErrBadFormat
+		}
+		last = e.unit
+
+		switch e.unit {
+		case Year:
+			if c.noYears {
+				return nil, ErrNoYear
 			}
-		case "second":
-			d += time.Duration(whole) * time.Second
-			if frac != 0 {
-				d += time.Duration(frac * float64(time.Second))
+		case Month:
+			if !c.months {
+				return nil, c.monthError(s, elems, i)
 			}
+		case Week:
+			weekElem = true
 		}
-		numElems++
 	}
 
-	// There must be at least one element in the string
-	if numElems == 0 {
-		return 0, ErrBadFormat
+	// Week elements, when used, must be the only elements in the string
+	if weekElem && distinct > 1 && !c.mixedWeeks {
+		return nil, ErrBadFormat
 	}
 
-	// Week elements, when used, must be the only elements in the string
-	if weekElem > 0 && numElems > 1 {
-		return 0, ErrBadFormat
+	if neg {
+		for i := range elems {
+			elems[i].whole, elems[i].frac = -elems[i].whole, -elems[i].frac
+		}
 	}
+	return elems, nil
+}
 
-	return d, nil
+// monthError describes the month element elems[i] of s. When the element
+// would form a valid duration as minutes instead, the error suggests it.
+func (c *parseConfig) monthError(s string, elems []element, i int) error {
+	e := elems[i]
+	err := &MonthError{Input: s, Offset: e.pos, Element: s[e.pos:e.end]}
+	if i == len(elems)-1 && !strings.Contains(s, "T") {
+		alt := s[:e.pos] + "T" + s[e.pos:]
+		if _, altErr := c.elements(alt); altErr == nil {
+			err.Suggestion = alt
+		}
+	}
+	return err
 }
 
 func parseDecimal(s string) (whole int64, frac float64, hasFrac bool, err error) {
@@ -130,69 +203,345 @@ func parseDecimal(s string) (whole int64, frac float64, hasFrac bool, err error)
 }
 
 // Format returns a string representation of a time.Duration value using ISO8601
-// formatting. Negative duration values are not supported.
+// formatting. Negative duration values are not supported; see AllowNegative.
 func Format(d time.Duration) (string, error) {
-	if d < 0 {
-		return "", ErrNoNegative
+	return FormatWithOptions(d)
+}
+
+// FormatWithOptions is like Format but accepts options that adjust the output.
+// With no options it behaves exactly like Format. Durations that Rounding
+// would take beyond the range of time.Duration fail with ErrRange.
+func FormatWithOptions(d time.Duration, opts ...FormatOption) (string, error) {
+	c := newFormatConfig(opts)
+	return c.format(d)
+}
+
+// AppendFormat is like Format but appends the textual representation to dst
+// and returns the extended buffer. It does not allocate when dst has enough
+// capacity. Negative durations are not supported: for them dst is returned
+// unchanged, so callers that need to detect this should check d first.
+func AppendFormat(dst []byte, d time.Duration) []byte {
+	b, err := defaultFormat.append(dst, d)
+	if err != nil {
+		return dst
+	}
+	return b
+}
+
+// FormatTo is like Format but writes the textual representation to w. It
+// returns ErrNoNegative for negative durations, or any error from w.
+func FormatTo(w io.Writer, d time.Duration) error {
+	var buf [32]byte
+	b, err := defaultFormat.append(buf[:0], d)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Canonicalize parses s and returns it re-formatted by Format, so that
+// equivalent durations such as "PT90M" and "PT1H30M" yield the same string.
+func Canonicalize(s string) (string, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Format(d)
+}
+
+// formatUnits lists the units written by Format, most significant first.
+var formatUnits = [...]Unit{Year, Day, Hour, Minute, Second}
+
+func (c *formatConfig) format(d time.Duration) (string, error) {
+	var buf [32]byte
+	b, err := c.append(buf[:0], d)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (c *formatConfig) append(b []byte, d time.Duration) ([]byte, error) {
+	if c.java {
+		return appendJava(b, d), nil
+	}
+	neg := d < 0
+	if neg {
+		if !c.negative {
+			return b, ErrNoNegative
+		}
+		if d = -d; d < 0 {
+			return b, ErrRange
+		}
+	}
+	r, ok := c.rounded(d)
+	if !ok {
+		return b, ErrRange
+	}
+	if neg && r != 0 {
+		b = append(b, '-')
+	}
+	return c.appendAbs(b, d), nil
+}
+
+// rounded returns d rounded as it will be written by appendAbs, and false if
+// it cannot be.
+func (c *formatConfig) rounded(d time.Duration) (time.Duration, bool) {
+	switch {
+	case c.fixed:
+		return d.Round(pow10[9-c.fixedDigits()]), true
+	case c.single || c.maxComponents > 0:
+		r := c.resolve(d)
+		return r.round(d)
+	}
+	return c.round(d)
+}
+
+// appendAbs appends d, which must not be negative, without a sign.
+func (c *formatConfig) appendAbs(b []byte, d time.Duration) []byte {
+	if c.fixed {
+		return c.appendFixed(b, d)
+	}
+
+	if c.single || c.maxComponents > 0 {
+		r := c.resolve(d)
+		return r.appendAbs(b, d)
+	}
+
+	d, _ = c.round(d)
+
+	if c.allZeros {
+		return c.appendAll(b, d)
 	}
 
-	s := bytes.NewBufferString("P")
 	if d == 0 {
-		s.WriteString("0Y")
-		goto done
+		if c.zero != "" {
+			return append(b, c.zero...)
+		}
+		return append(b, "P0Y"...)
+	}
+
+	b = append(b, 'P')
+
+	if c.weeks && c.largest <= Week && d%weekTime == 0 {
+		b = strconv.AppendInt(b, int64(d/weekTime), 10)
+		return append(b, 'W')
 	}
 
-	if f := d / yearTime; f >= 1 {
-		fmt.Fprintf(s, "%dY", f)
-		d -= f * yearTime
-		if d == 0 {
-			goto done
+	var inTime bool
+	prev := Unit(-1)
+	for _, u := range formatUnits {
+		if u < c.largest {
+			continue
+		}
+
+		t := unitTimes[u]
+		if u < c.smallest && d < t && !(c.noGaps && u == Minute && prev == Hour) {
+			continue
+		}
+
+		if u == Day && c.mixedWeeks && d >= weekTime {
+			b = strconv.AppendInt(b, int64(d/weekTime), 10)
+			b = append(b, 'W')
+			if d %= weekTime; d == 0 {
+				break
+			}
+			if d < t && u < c.smallest {
+				continue
+			}
+		}
+
+		if u.isTime() && !inTime {
+			b = append(b, 'T')
+			inTime = true
+		}
+		if u == Day && c.noGaps && prev == Year {
+			b = append(b, "0M"...)
+		}
+		if u == c.smallest {
+			b = c.appendDecimal(b, d, u, c.trimZeros)
+			b = append(b, u.Designator())
+			break
+		}
+
+		b = strconv.AppendInt(b, int64(d/t), 10)
+		b = append(b, u.Designator())
+		prev = u
+		if d %= t; d == 0 {
+			break
 		}
 	}
 
-	if f := d / dayTime; f >= 1 {
-		fmt.Fprintf(s, "%dD", f)
-		d -= f * dayTime
-		if d == 0 {
-			goto done
+	return b
+}
+
+// appendFixed appends d in the fixed-width form "P0000Y000DT00H00M00.000S".
+func (c *formatConfig) appendFixed(b []byte, d time.Duration) []byte {
+	digits := c.fixedDigits()
+	d = d.Round(pow10[9-digits])
+
+	b = append(b, 'P')
+	for _, f := range [...]struct {
+		unit  Unit
+		width int
+	}{{Year, 4}, {Day, 3}, {Hour, 2}, {Minute, 2}, {Second, 2}} {
+		t := unitTimes[f.unit]
+		if f.unit == Hour {
+			b = append(b, 'T')
+		}
+		b = appendPadded(b, int64(d/t), f.width)
+		if f.unit == Second && digits > 0 {
+			b = append(b, c.separator())
+			b = appendPadded(b, int64(d%t/pow10[9-digits]), digits)
 		}
+		b = append(b, f.unit.Designator())
+		d %= t
 	}
+	return b
+}
 
-	s.WriteString("T")
+// appendAll appends d with every element from the largest to the smallest
+// unit, including zeros and an always-zero month (e.g. "P0Y0M0DT0H0M5S").
+func (c *formatConfig) appendAll(b []byte, d time.Duration) []byte {
+	b = append(b, 'P')
 
-	if f := d / time.Hour; f >= 1 {
-		fmt.Fprintf(s, "%dH", f)
-		d -= f * time.Hour
-		if d == 0 {
-			goto done
+	var inTime bool
+	for _, u := range [...]Unit{Year, Month, Day, Hour, Minute, Second} {
+		if u < c.largest || u > c.smallest {
+			continue
+		}
+		if u.isTime() && !inTime {
+			b = append(b, 'T')
+			inTime = true
+		}
+
+		if u == Month {
+			b = append(b, "0M"...)
+			continue
+		}
+
+		t := unitTimes[u]
+		if u == c.smallest {
+			b = c.appendDecimal(b, d, u, c.trimZeros)
+		} else {
+			b = strconv.AppendInt(b, int64(d/t), 10)
+			d %= t
+		}
+		b = append(b, u.Designator())
+	}
+	return b
+}
+
+// fixedDigits returns the number of fractional digits written by appendFixed.
+func (c *formatConfig) fixedDigits() int {
+	if c.digits < 0 {
+		return 3
+	}
+	return c.digits
+}
+
+// appendPadded appends the decimal form of n, which must not be negative,
+// padded with leading zeros to at least width digits.
+func appendPadded(b []byte, n int64, width int) []byte {
+	var buf [20]byte
+	s := strconv.AppendInt(buf[:0], n, 10)
+	for i := len(s); i < width; i++ {
+		b = append(b, '0')
+	}
+	return append(b, s...)
+}
+
+// resolve returns a copy of c with the largest and smallest units fixed as
+// required by the single unit and component limit options for d.
+func (c *formatConfig) resolve(d time.Duration) formatConfig {
+	r := *c
+	r.single, r.maxComponents = false, 0
+
+	if c.single {
+		for _, u := range formatUnits {
+			if u >= c.largest && (d >= unitTimes[u] || u == Second) {
+				r.largest = u
+				break
+			}
 		}
+		r.smallest = r.largest
+		return r
 	}
 
-	if f := d / time.Minute; f >= 1 {
-		fmt.Fprintf(s, "%dM", f)
-		d -= f * time.Minute
-		if d == 0 {
-			goto done
+	n := 0
+	for _, u := range formatUnits {
+		if u < c.largest || u > c.smallest {
+			continue
+		}
+		if t := unitTimes[u]; d >= t || u == c.smallest {
+			r.smallest = u
+			if n++; n == c.maxComponents {
+				break
+			}
+			d %= t
 		}
 	}
+	return r
+}
 
-	if d%time.Second == 0 {
-		fmt.Fprintf(s, "%dS", d/time.Second)
-		goto done
+// round rounds d as required by the smallest unit and precision options, and
+// reports false if the result is clamped as by roundTo.
+func (c *formatConfig) round(d time.Duration) (time.Duration, bool) {
+	t := unitTimes[c.smallest]
+	switch {
+	case c.rounding != nil:
+		return roundTo(d, t, *c.rounding)
+	case c.digits >= 0:
+		return d.Round(t / pow10[c.digits]), true
 	}
+	return d.Round(t / pow10[9]), true
+}
+
+// appendDecimal appends d, which must be less than a unit larger than u, as a
+// decimal number of units u. Unless a precision is set, a seconds fraction is
+// written to millisecond, microsecond or nanosecond precision, whichever is the
+// coarsest that represents d exactly, and other fractions to at most nine
+// digits. If trim is set, trailing zeros are removed from the fraction.
+func (c *formatConfig) appendDecimal(b []byte, d time.Duration, u Unit, trim bool) []byte {
+	t := unitTimes[u]
+	b = strconv.AppendInt(b, int64(d/t), 10)
 
-	if d%time.Millisecond == 0 {
-		fmt.Fprintf(s, "%.3fS", float64(d)/float64(time.Second))
-		goto done
+	frac := d % t / (t / pow10[9])
+	digits := c.digits
+	if digits < 0 {
+		switch {
+		case frac == 0:
+			digits = 0
+		case u != Second:
+			digits, trim = 9, true
+		case frac%time.Millisecond == 0:
+			digits = 3
+		case frac%time.Microsecond == 0:
+			digits = 6
+		default:
+			digits = 9
+		}
 	}
 
-	if d%time.Microsecond == 0 {
-		fmt.Fprintf(s, "%.6fS", float64(d)/float64(time.Second))
-		goto done
+	// Drop the digits beyond the precision, then any trailing zeros
+	frac /= pow10[9-digits]
+	if trim {
+		for digits > 0 && frac%10 == 0 {
+			frac /= 10
+			digits--
+		}
 	}
 
-	fmt.Fprintf(s, "%.9fS", float64(d)/float64(time.Second))
+	if digits > 0 {
+		b = append(b, c.separator())
+		for i := digits - 1; i >= 0; i-- {
+			b = append(b, byte('0'+frac/pow10[i]%10))
+		}
+	}
 
-done:
-	return s.String(), nil
+	return b
 }
+
+// pow10 holds the durations 1ns through 1s in powers of ten.
+var pow10 = [...]time.Duration{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
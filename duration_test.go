@@ -1,6 +1,9 @@
 package duration
 
 import (
+	"bytes"
+	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -72,6 +75,11 @@ func TestParseGivenInvalid(t *testing.T) {
 		{"P1Y1W", ErrBadFormat},
 		{"P1S", ErrBadFormat},
 
+		// With repeated designators
+		{"PT1H1H", ErrDuplicate},
+		{"P1Y1Y", ErrDuplicate},
+		{"PT1H30M1H", ErrDuplicate},
+
 		// With month
 		{"P0M", ErrNoMonth},
 		{"P1M", ErrNoMonth},
@@ -85,12 +93,209 @@ func TestParseGivenInvalid(t *testing.T) {
 	for _, vec := range vecs {
 		d, err := Parse(vec.in)
 		if assert.Error(t, err, vec.in) {
-			assert.Equal(t, vec.err, err, vec.in)
+			assert.ErrorIs(t, err, vec.err, vec.in)
 		}
 		assert.Equal(t, time.Duration(0), d, vec.in)
 	}
 }
 
+func TestParseWithOptionsGivenOutOfOrder(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"PT5S1H", time.Hour + 5*time.Second, nil},
+		{"P2D1YT4M3H", yearTime + 2*dayTime + 3*time.Hour + 4*time.Minute, nil},
+		{"PT30M0.5H", time.Hour, nil},
+
+		// Still invalid
+		{"PT1H1H", 0, ErrDuplicate},
+		{"P5S1Y", 0, ErrBadFormat},
+		{"PT0.5H30M", 0, ErrBadFormat},
+		{"P1D1M", 0, ErrNoMonth},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, AllowOutOfOrder())
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+
+		if vec.err == nil {
+			_, err = Parse(vec.in)
+			assert.Equal(t, ErrBadFormat, err, vec.in)
+		}
+	}
+}
+
+func TestParseWithOptionsGivenRepeated(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"PT1H1H30M", 2*time.Hour + 30*time.Minute, nil},
+		{"P1D1DT1S1S", 2*dayTime + 2*time.Second, nil},
+		{"P1W1W", 2 * weekTime, nil},
+		{"PT1S0.5S", 1500 * time.Millisecond, nil},
+
+		// Still invalid
+		{"PT0.5S1S", 0, ErrBadFormat},
+		{"PT1H30M1H", 0, ErrBadFormat},
+		{"P1W1W1D", 0, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, AllowRepeated())
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	d, err := ParseWithOptions("PT1H30M1H", AllowRepeated(), AllowOutOfOrder())
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, d)
+}
+
+func TestParseSeconds(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out float64
+		err error
+	}{
+		{"PT1H30M", 5400, nil},
+		{"PT0.5S", 0.5, nil},
+		{"PT0.0000000001S", 1e-10, nil},
+		{"P1DT0.25S", 86400.25, nil},
+		{"P2W", 1209600, nil},
+
+		{"P1M", 0, ErrNoMonth},
+		{"PT", 0, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		s, err := ParseSeconds(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.InDelta(t, vec.out, s, 1e-15, vec.in)
+	}
+}
+
+func TestParseAs(t *testing.T) {
+	t.Parallel()
+
+	ns, err := ParseAs[int64]("PT1.5S")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000000), ns)
+
+	secs, err := ParseAs[float64]("PT1.5S")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, secs)
+
+	d, err := ParseAs[time.Duration]("PT1.5S")
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	_, err = ParseAs[int64]("P1M")
+	assert.ErrorIs(t, err, ErrNoMonth)
+	_, err = ParseAs[float64]("asdf")
+	assert.Equal(t, ErrBadFormat, err)
+	_, err = ParseAs[time.Duration]("")
+	assert.Equal(t, ErrBadFormat, err)
+}
+
+func TestParseWithOptionsGivenRejectYears(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"P2DT1H", 2*dayTime + time.Hour, nil},
+		{"P1W", weekTime, nil},
+
+		{"P1Y", 0, ErrNoYear},
+		{"P0Y", 0, ErrNoYear},
+		{"P1.5Y", 0, ErrNoYear},
+		{"P1Y2DT3H", 0, ErrNoYear},
+		{"P1Y1M", 0, ErrNoYear},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, RejectYears())
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestParseWithOptionsGivenTrim(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		mode TrimMode
+		out  time.Duration
+		err  error
+	}{
+		{" PT1S\t", TrimSpace, time.Second, nil},
+		{"PT1S\r\n", TrimSpace, time.Second, nil},
+
+		{"PT1S", TrimNone, time.Second, nil},
+		{" PT1S", TrimNone, 0, ErrBadFormat},
+		{"PT1S ", TrimNone, 0, ErrBadFormat},
+		{"PT1S\n", TrimNone, 0, ErrBadFormat},
+
+		{"PT1S", TrimLineEnding, time.Second, nil},
+		{"PT1S\n", TrimLineEnding, time.Second, nil},
+		{"PT1S\r\n", TrimLineEnding, time.Second, nil},
+		{"PT1S\n\n", TrimLineEnding, 0, ErrBadFormat},
+		{" PT1S\n", TrimLineEnding, 0, ErrBadFormat},
+		{"PT1S \n", TrimLineEnding, 0, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, Trim(vec.mode))
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	_, _, err := ParseQualified(" PT1S~", Trim(TrimNone))
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestParseWithOptionsGivenDecimalSeparator(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		sep Separator
+		out time.Duration
+		err error
+	}{
+		{"PT1.5S", SeparatorAny, 1500 * time.Millisecond, nil},
+		{"PT1,5S", SeparatorAny, 1500 * time.Millisecond, nil},
+
+		{"PT1.5S", SeparatorDot, 1500 * time.Millisecond, nil},
+		{"PT1,5S", SeparatorDot, 0, ErrBadFormat},
+
+		{"PT1,5S", SeparatorComma, 1500 * time.Millisecond, nil},
+		{"PT1.5S", SeparatorComma, 0, ErrBadFormat},
+
+		{"PT1S", SeparatorComma, time.Second, nil},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, DecimalSeparator(vec.sep))
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
 func TestFormatGivenValid(t *testing.T) {
 	t.Parallel()
 
@@ -159,8 +364,528 @@ func TestFormatGivenInvalid(t *testing.T) {
 	for _, vec := range vecs {
 		s, err := Format(vec.in)
 		if assert.Error(t, err, vec.in) {
-			assert.Equal(t, vec.err, err, vec.in)
+			assert.ErrorIs(t, err, vec.err, vec.in)
 		}
 		assert.Empty(t, s, vec.in)
 	}
 }
+
+func TestFormatWithOptionsGivenNone(t *testing.T) {
+	t.Parallel()
+
+	for _, d := range []time.Duration{0, time.Nanosecond, time.Hour + time.Second, yearTime + dayTime} {
+		want, err := Format(d)
+		assert.NoError(t, err, d)
+
+		s, err := FormatWithOptions(d)
+		assert.NoError(t, err, d)
+		assert.Equal(t, want, s, d)
+	}
+
+	_, err := FormatWithOptions(-time.Second)
+	assert.ErrorIs(t, err, ErrNoNegative)
+}
+
+func TestFormatWithOptionsGivenUseWeeks(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{weekTime, "P1W"},
+		{2 * weekTime, "P2W"},
+		{53 * weekTime, "P53W"},
+
+		// Not a multiple of a week
+		{weekTime + time.Second, "P7DT1S"},
+		{dayTime, "P1D"},
+		{yearTime, "P1Y"},
+		{0, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, UseWeeks())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenZeroAs(t *testing.T) {
+	t.Parallel()
+
+	for _, z := range []Zero{ZeroYears, ZeroDays, ZeroSeconds} {
+		s, err := FormatWithOptions(0, ZeroAs(z))
+		assert.NoError(t, err, z)
+		assert.Equal(t, string(z), s, z)
+
+		d, err := Parse(s)
+		assert.NoError(t, err, z)
+		assert.Equal(t, time.Duration(0), d, z)
+
+		s, err = FormatWithOptions(time.Second, ZeroAs(z))
+		assert.NoError(t, err, z)
+		assert.Equal(t, "PT1S", s, z)
+	}
+}
+
+func TestFormatWithOptionsGivenDecimalSeparator(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		sep Separator
+		out string
+	}{
+		{1500 * time.Millisecond, SeparatorAny, "PT1.500S"},
+		{1500 * time.Millisecond, SeparatorDot, "PT1.500S"},
+		{1500 * time.Millisecond, SeparatorComma, "PT1,500S"},
+		{time.Hour + time.Microsecond, SeparatorComma, "PT1H0,000001S"},
+		{time.Second, SeparatorComma, "PT1S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, DecimalSeparator(vec.sep))
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		d, err := ParseWithOptions(s, DecimalSeparator(vec.sep))
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.in, d, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenPrecision(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{1500 * time.Millisecond, []FormatOption{Precision(0)}, "PT2S"},
+		{1500 * time.Millisecond, []FormatOption{Precision(1)}, "PT1.5S"},
+		{1500 * time.Millisecond, []FormatOption{Precision(6)}, "PT1.500000S"},
+		{time.Second, []FormatOption{Precision(3)}, "PT1.000S"},
+		{time.Nanosecond, []FormatOption{Precision(9)}, "PT0.000000001S"},
+		{time.Nanosecond, []FormatOption{Precision(12)}, "PT0.000000001S"},
+		{1234567 * time.Microsecond, []FormatOption{Precision(3)}, "PT1.235S"},
+		{59999600 * time.Microsecond, []FormatOption{Precision(3)}, "PT1M"},
+		{time.Hour, []FormatOption{Precision(3)}, "PT1H"},
+		{time.Millisecond, []FormatOption{Precision(2)}, "P0Y"},
+
+		{1500 * time.Millisecond, []FormatOption{TrimZeros()}, "PT1.5S"},
+		{time.Second + 10*time.Microsecond, []FormatOption{TrimZeros()}, "PT1.00001S"},
+		{1500 * time.Millisecond, []FormatOption{Precision(6), TrimZeros()}, "PT1.5S"},
+		{1001 * time.Millisecond, []FormatOption{Precision(2), TrimZeros()}, "PT1S"},
+		{time.Second, []FormatOption{TrimZeros()}, "PT1S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenUnits(t *testing.T) {
+	t.Parallel()
+
+	d := time.Hour + 30*time.Minute + 45*time.Second
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{d, []FormatOption{LargestUnit(Minute), SmallestUnit(Minute)}, "PT90.75M"},
+		{d, []FormatOption{SmallestUnit(Minute)}, "PT1H30.75M"},
+		{d, []FormatOption{SmallestUnit(Minute), Rounding(RoundHalfExpand)}, "PT1H31M"},
+		{d, []FormatOption{SmallestUnit(Minute), Rounding(RoundTrunc)}, "PT1H30M"},
+		{d, []FormatOption{SmallestUnit(Hour), Rounding(RoundCeil)}, "PT2H"},
+		{d, []FormatOption{LargestUnit(Second)}, "PT5445S"},
+		{time.Minute + time.Second, []FormatOption{SmallestUnit(Minute), Precision(2)}, "PT1.02M"},
+		{time.Minute + time.Second, []FormatOption{SmallestUnit(Minute)}, "PT1.016666667M"},
+
+		{2*dayTime + time.Hour, []FormatOption{LargestUnit(Hour)}, "PT49H"},
+		{yearTime + 12*time.Hour, []FormatOption{LargestUnit(Day)}, "P365DT12H"},
+		{yearTime + 12*time.Hour, []FormatOption{SmallestUnit(Day)}, "P1Y0.5D"},
+		{36 * time.Hour, []FormatOption{SmallestUnit(Week)}, "P1.5D"},
+		{2 * weekTime, []FormatOption{UseWeeks(), LargestUnit(Day)}, "P14D"},
+
+		// Smallest unit wins over a conflicting largest unit
+		{d, []FormatOption{LargestUnit(Second), SmallestUnit(Hour)}, "PT1.5125H"},
+
+		// Rounded to zero
+		{29 * time.Second, []FormatOption{SmallestUnit(Minute), Rounding(RoundHalfExpand)}, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenSingleUnit(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{90 * time.Minute, nil, "PT1.5H"},
+		{2*dayTime + 6*time.Hour, nil, "P2.25D"},
+		{yearTime + 73*dayTime, nil, "P1.2Y"},
+		{90 * time.Second, nil, "PT1.5M"},
+		{1500 * time.Millisecond, nil, "PT1.500S"},
+		{500 * time.Millisecond, nil, "PT0.500S"},
+		{time.Hour, nil, "PT1H"},
+		{0, nil, "P0Y"},
+
+		{2*dayTime + 6*time.Hour, []FormatOption{LargestUnit(Hour)}, "PT54H"},
+		{100 * time.Minute, []FormatOption{Precision(2)}, "PT1.67H"},
+		{1500 * time.Millisecond, []FormatOption{TrimZeros()}, "PT1.5S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, SingleUnit())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenSecondsOnly(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{90 * time.Minute, "PT5400S"},
+		{dayTime + 1500*time.Millisecond, "PT86401.500S"},
+		{yearTime, "PT31536000S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{0, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, SecondsOnly())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestAppendFormat(t *testing.T) {
+	t.Parallel()
+
+	vecs := []time.Duration{
+		0,
+		time.Nanosecond,
+		time.Second + time.Millisecond,
+		time.Hour + time.Minute,
+		yearTime + 10*dayTime + time.Hour + time.Minute + time.Second + time.Millisecond,
+	}
+
+	for _, d := range vecs {
+		want, err := Format(d)
+		assert.NoError(t, err, d)
+		assert.Equal(t, "x="+want, string(AppendFormat([]byte("x="), d)), d)
+	}
+
+	assert.Equal(t, "x=", string(AppendFormat([]byte("x="), -time.Second)))
+}
+
+func TestAppendFormatAllocs(t *testing.T) {
+	d := yearTime + 10*dayTime + time.Hour + time.Minute + time.Second + time.Millisecond
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendFormat(buf[:0], d)
+	})
+	assert.Zero(t, allocs)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestFormatTo(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.NoError(t, FormatTo(&buf, time.Hour+time.Millisecond))
+	assert.NoError(t, FormatTo(&buf, 0))
+	assert.Equal(t, "PT1H0.001SP0Y", buf.String())
+
+	buf.Reset()
+	assert.ErrorIs(t, FormatTo(&buf, -time.Second), ErrNoNegative)
+	assert.Zero(t, buf.Len())
+
+	assert.EqualError(t, FormatTo(errWriter{}, time.Second), "write failed")
+}
+
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"PT90M", "PT1H30M", nil},
+		{"PT1H30M", "PT1H30M", nil},
+		{" P1W ", "P7D", nil},
+		{"PT0,5S", "PT0.500S", nil},
+		{"P0D", "P0Y", nil},
+		{"PT36H", "P1DT12H", nil},
+
+		{"P1M", "", ErrNoMonth},
+		{"asdf", "", ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		s, err := Canonicalize(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenMaxComponents(t *testing.T) {
+	t.Parallel()
+
+	d := yearTime + 10*dayTime + time.Hour + time.Minute + time.Second + time.Millisecond
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{d, []FormatOption{MaxComponents(2), Rounding(RoundTrunc)}, "P1Y10D"},
+		{d, []FormatOption{MaxComponents(3), Rounding(RoundHalfExpand)}, "P1Y10DT1H"},
+		{d, []FormatOption{MaxComponents(5)}, "P1Y10DT1H1M1.001S"},
+		{d, []FormatOption{MaxComponents(0)}, "P1Y10DT1H1M1.001S"},
+		{90 * time.Minute, []FormatOption{MaxComponents(1)}, "PT1.5H"},
+		{time.Hour + time.Second, []FormatOption{MaxComponents(2)}, "PT1H1S"},
+		{dayTime - time.Second, []FormatOption{MaxComponents(1), Rounding(RoundHalfExpand)}, "P1D"},
+		{2 * dayTime, []FormatOption{MaxComponents(1)}, "P2D"},
+		{0, []FormatOption{MaxComponents(1)}, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenFixedWidth(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{0, nil, "P0000Y000DT00H00M00.000S"},
+		{90 * time.Minute, nil, "P0000Y000DT01H30M00.000S"},
+		{yearTime + 10*dayTime + time.Hour + time.Minute + time.Second + time.Millisecond, nil, "P0001Y010DT01H01M01.001S"},
+		{1500 * time.Microsecond, nil, "P0000Y000DT00H00M00.002S"},
+		{1500 * time.Microsecond, []FormatOption{Precision(6)}, "P0000Y000DT00H00M00.001500S"},
+		{1500 * time.Millisecond, []FormatOption{Precision(0)}, "P0000Y000DT00H00M02S"},
+		{time.Second, []FormatOption{DecimalSeparator(SeparatorComma)}, "P0000Y000DT00H00M01,000S"},
+		{2 * weekTime, []FormatOption{UseWeeks()}, "P0000Y014DT00H00M00.000S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, FixedWidth())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		d, err := Parse(s)
+		assert.NoError(t, err, vec.in)
+		assert.InDelta(t, float64(vec.in), float64(d), float64(time.Second/2), vec.in)
+	}
+
+	// Lexicographic order follows duration order
+	a, _ := FormatWithOptions(59*time.Minute, FixedWidth())
+	b, _ := FormatWithOptions(dayTime, FixedWidth())
+	assert.Less(t, a, b)
+}
+
+func TestFormatWithOptionsGivenIncludeZeros(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{5 * time.Second, nil, "P0Y0M0DT0H0M5S"},
+		{0, nil, "P0Y0M0DT0H0M0S"},
+		{yearTime + 10*dayTime + time.Hour + time.Minute + time.Second + time.Millisecond, nil, "P1Y0M10DT1H1M1.001S"},
+		{36 * time.Hour, []FormatOption{LargestUnit(Hour)}, "PT36H0M0S"},
+		{90 * time.Second, []FormatOption{LargestUnit(Minute)}, "PT1M30S"},
+		{90 * time.Minute, []FormatOption{SmallestUnit(Hour)}, "P0Y0M0DT1.5H"},
+		{dayTime, []FormatOption{SmallestUnit(Day)}, "P0Y0M1D"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, IncludeZeros())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenNoGaps(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{time.Hour + time.Second, nil, "PT1H0M1S"},
+		{time.Hour + 1500*time.Millisecond, nil, "PT1H0M1.500S"},
+		{yearTime + 2*dayTime, nil, "P1Y0M2D"},
+		{yearTime + 2*dayTime + time.Hour + time.Second, nil, "P1Y0M2DT1H0M1S"},
+		{yearTime + time.Hour, nil, "P1YT1H"},
+		{dayTime + time.Second, nil, "P1DT1S"},
+		{90 * time.Minute, nil, "PT1H30M"},
+		{time.Hour, nil, "PT1H"},
+		{yearTime + 2*dayTime, []FormatOption{LargestUnit(Day)}, "P367D"},
+		{0, nil, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, NoGaps())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestFormatWithOptionsGivenAllowNegative(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{-90 * time.Minute, nil, "-PT1H30M"},
+		{-(dayTime + 2*time.Hour), nil, "-P1DT2H"},
+		{-1500 * time.Millisecond, nil, "-PT1.500S"},
+		{-2 * weekTime, []FormatOption{UseWeeks()}, "-P2W"},
+		{-400 * time.Millisecond, []FormatOption{Precision(0)}, "P0Y"},
+		{-90 * time.Minute, []FormatOption{SingleUnit()}, "-PT1.5H"},
+		{90 * time.Minute, nil, "PT1H30M"},
+		{0, nil, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, AllowNegative())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		if vec.in < 0 {
+			_, err = FormatWithOptions(vec.in, vec.opts...)
+			assert.ErrorIs(t, err, ErrNoNegative, vec.in)
+		}
+	}
+
+	_, err := FormatWithOptions(time.Duration(math.MinInt64), AllowNegative())
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestParseWithOptionsGivenAllowNegative(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"-PT1H", -time.Hour},
+		{"-P1DT2H30M", -(dayTime + 2*time.Hour + 30*time.Minute)},
+		{"-PT0.500S", -500 * time.Millisecond},
+		{"PT1H", time.Hour},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, AllowNegative())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+
+		s, err := FormatWithOptions(d, AllowNegative())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.in, s, vec.in)
+	}
+
+	for _, in := range []string{"-PT1H", "--PT1H", "P-1D", "PT-1H", "-", "-P"} {
+		_, err := Parse(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+	for _, in := range []string{"--PT1H", "P-1D", "-PT-1H", "-", "-P", "+PT1H"} {
+		_, err := ParseWithOptions(in, AllowNegative())
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+}
+
+func TestFormatWithOptionsGivenMixedWeeks(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{17 * dayTime, nil, "P2W3D"},
+		{14 * dayTime, nil, "P2W"},
+		{6 * dayTime, nil, "P6D"},
+		{yearTime + 15*dayTime + time.Hour, nil, "P1Y2W1DT1H"},
+		{7*dayTime + time.Hour, nil, "P1WT1H"},
+		{7*dayTime + 12*time.Hour, []FormatOption{SmallestUnit(Day)}, "P1W0.5D"},
+		{7 * dayTime, []FormatOption{LargestUnit(Hour)}, "PT168H"},
+		{-17 * dayTime, []FormatOption{AllowNegative()}, "-P2W3D"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, append(vec.opts, MixedWeeks())...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	s, err := Format(17 * dayTime)
+	assert.NoError(t, err)
+	assert.Equal(t, "P17D", s)
+}
+
+func TestParseWithOptionsGivenMixedWeeks(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"P2W3D", 17 * dayTime},
+		{"P1Y2W1DT1H", yearTime + 15*dayTime + time.Hour},
+		{"P1WT1H", weekTime + time.Hour},
+		{"P1.5W", weekTime + 84*time.Hour},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, MixedWeeks())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+
+		_, err = Parse(vec.in)
+		if vec.in != "P1.5W" {
+			assert.ErrorIs(t, err, ErrBadFormat, vec.in)
+		}
+	}
+
+	_, err := ParseWithOptions("P3D2W", MixedWeeks())
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
@@ -0,0 +1,112 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// signedFormat is the configuration used by the methods of Duration.
+var signedFormat = formatConfig{digits: -1, largest: Year, smallest: Second, negative: true}
+
+// parseSigned parses s as the methods of Duration do.
+func parseSigned(s string) (time.Duration, error) {
+	return ParseWithOptions(s, AllowNegative())
+}
+
+// MarshalJSON implements json.Marshaler, encoding d as an ISO8601 string
+// (e.g. "PT1H30M").
+func (d Duration) MarshalJSON() ([]byte, error) {
+	b, err := signedFormat.append([]byte{'"'}, time.Duration(d))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts an ISO8601 string, or
+// a number of seconds such as 5400 or 0.25 for clients that send numeric
+// durations. A JSON null leaves d unchanged.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case string(b) == "null":
+		return nil
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrBadFormat
+		}
+		v, err := parseSigned(s)
+		if err != nil {
+			return err
+		}
+		*d = Duration(v)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return ErrBadFormat
+	}
+	v, err := fromSeconds(f)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// fromSeconds converts a number of seconds to a time.Duration, rounding to the
+// nearest nanosecond. It fails with ErrRange if the result is out of range.
+func fromSeconds(secs float64) (time.Duration, error) {
+	f := math.Round(secs * float64(time.Second))
+	if f >= math.MaxInt64 || f < math.MinInt64 || math.IsNaN(f) {
+		return 0, ErrRange
+	}
+	return time.Duration(f), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding d as an ISO8601
+// string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return signedFormat.append(nil, time.Duration(d))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing an ISO8601
+// string with a leading "-" permitted.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := parseSigned(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// binaryVersion is the first byte of the layout written by MarshalBinary.
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler, and so gob encoding. The
+// layout is a version byte, currently 1, followed by the number of
+// nanoseconds as a zig-zag varint as written by binary.AppendVarint.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return binary.AppendVarint([]byte{binaryVersion}, int64(d)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Data with an unknown
+// version or a malformed value fails with ErrBadFormat.
+func (d *Duration) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 || b[0] != binaryVersion {
+		return ErrBadFormat
+	}
+	v, n := binary.Varint(b[1:])
+	if n != len(b)-1 {
+		return ErrBadFormat
+	}
+	*d = Duration(v)
+	return nil
+}
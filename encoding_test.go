@@ -0,0 +1,154 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Duration
+		out string
+	}{
+		{Duration(90 * time.Minute), `"PT1H30M"`},
+		{Duration(0), `"P0Y"`},
+		{Duration(-1500 * time.Millisecond), `"-PT1.500S"`},
+	}
+
+	for _, vec := range vecs {
+		b, err := json.Marshal(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, string(b), vec.in)
+	}
+
+	_, err := json.Marshal(Duration(math.MinInt64))
+	assert.ErrorIs(t, err, ErrRange)
+
+	b, err := json.Marshal(struct {
+		Timeout Duration `json:"timeout"`
+	}{Duration(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"timeout":"PT1M"}`, string(b))
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Duration
+	}{
+		{`"PT1H30M"`, Duration(90 * time.Minute)},
+		{`"-PT1S"`, Duration(-time.Second)},
+		{`"P1DT2H"`, Duration(26 * time.Hour)},
+		{`5400`, Duration(90 * time.Minute)},
+		{`0.25`, Duration(250 * time.Millisecond)},
+		{`-1.5e0`, Duration(-1500 * time.Millisecond)},
+		{` 1 `, Duration(time.Second)},
+	}
+
+	for _, vec := range vecs {
+		var d Duration
+		assert.NoError(t, d.UnmarshalJSON([]byte(vec.in)), vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	d := Duration(time.Minute)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.Equal(t, Duration(time.Minute), d)
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{`"1h"`, ErrBadFormat},
+		{`"P1M"`, ErrNoMonth},
+		{`true`, ErrBadFormat},
+		{`"PT1H`, ErrBadFormat},
+		{`1e10`, ErrRange},
+		{`-1e10`, ErrRange},
+	}
+
+	for _, vec := range bad {
+		var d Duration
+		assert.ErrorIs(t, d.UnmarshalJSON([]byte(vec.in)), vec.err, vec.in)
+	}
+
+	var v struct {
+		Timeout Duration `json:"timeout"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"timeout":"PT2M"}`), &v))
+	assert.Equal(t, Duration(2*time.Minute), v.Timeout)
+}
+
+func TestDurationText(t *testing.T) {
+	t.Parallel()
+
+	b, err := Duration(90 * time.Minute).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "PT1H30M", string(b))
+
+	var d Duration
+	assert.NoError(t, d.UnmarshalText([]byte("-P1DT2H")))
+	assert.Equal(t, Duration(-26*time.Hour), d)
+	assert.ErrorIs(t, d.UnmarshalText([]byte("90m")), ErrBadFormat)
+
+	_, err = Duration(math.MinInt64).MarshalText()
+	assert.ErrorIs(t, err, ErrRange)
+
+	// Map keys are encoded through the text methods
+	b, err = json.Marshal(map[Duration]int{Duration(time.Hour): 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"PT1H":1}`, string(b))
+
+	var m map[Duration]int
+	assert.NoError(t, json.Unmarshal([]byte(`{"PT2H":2}`), &m))
+	assert.Equal(t, map[Duration]int{Duration(2 * time.Hour): 2}, m)
+
+	var x struct {
+		Timeout Duration `xml:"timeout"`
+	}
+	assert.NoError(t, xml.Unmarshal([]byte(`<x><timeout>PT5S</timeout></x>`), &x))
+	assert.Equal(t, Duration(5*time.Second), x.Timeout)
+}
+
+func TestDurationBinary(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []Duration{0, 1, -1, Duration(90 * time.Minute), Duration(-yearTime), math.MaxInt64, math.MinInt64} {
+		b, err := in.MarshalBinary()
+		assert.NoError(t, err, in)
+		assert.Equal(t, byte(1), b[0], in)
+
+		var d Duration
+		assert.NoError(t, d.UnmarshalBinary(b), in)
+		assert.Equal(t, in, d)
+	}
+
+	b, err := Duration(time.Second).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0x80, 0xa8, 0xd6, 0xb9, 0x07}, b)
+
+	var d Duration
+	for _, in := range [][]byte{nil, {1}, {2, 0}, {1, 0x80}, {1, 0, 0}} {
+		assert.ErrorIs(t, d.UnmarshalBinary(in), ErrBadFormat, in)
+	}
+
+	type snapshot struct {
+		Timeout Duration
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(snapshot{Duration(time.Minute)}))
+	var s snapshot
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&s))
+	assert.Equal(t, Duration(time.Minute), s.Timeout)
+}
@@ -0,0 +1,32 @@
+package duration
+
+import "fmt"
+
+// MonthError is returned when a duration string contains a month element. It
+// wraps ErrNoMonth, so errors.Is(err, ErrNoMonth) continues to work.
+type MonthError struct {
+	// Input is the duration string, with surrounding whitespace removed.
+	Input string
+
+	// Offset is the byte offset of the month element within Input.
+	Offset int
+
+	// Element is the offending element, e.g. "1M".
+	Element string
+
+	// Suggestion, when not empty, is Input with the element moved into the
+	// time part, i.e. read as minutes (e.g. "PT1M" for "P1M").
+	Suggestion string
+}
+
+func (e *MonthError) Error() string {
+	msg := fmt.Sprintf("%s: %q at offset %d", ErrNoMonth, e.Element, e.Offset)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s for minutes?)", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *MonthError) Unwrap() error {
+	return ErrNoMonth
+}
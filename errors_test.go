@@ -0,0 +1,51 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthError(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in         string
+		offset     int
+		elem       string
+		suggestion string
+	}{
+		{"P1M", 1, "1M", "PT1M"},
+		{" P30M ", 1, "30M", "PT30M"},
+		{"P1Y1M", 3, "1M", "P1YT1M"},
+		{"P1Y1.5M", 3, "1.5M", "P1YT1.5M"},
+
+		// No sensible reading as minutes
+		{"P1M1D", 1, "1M", ""},
+		{"P1MT1M", 1, "1M", ""},
+		{"P1Y1MT1H", 3, "1M", ""},
+	}
+
+	for _, vec := range vecs {
+		_, err := Parse(vec.in)
+
+		var merr *MonthError
+		if assert.True(t, errors.As(err, &merr), vec.in) {
+			assert.ErrorIs(t, err, ErrNoMonth, vec.in)
+			assert.Equal(t, vec.offset, merr.Offset, vec.in)
+			assert.Equal(t, vec.elem, merr.Element, vec.in)
+			assert.Equal(t, vec.suggestion, merr.Suggestion, vec.in)
+		}
+	}
+}
+
+func TestMonthErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse("P1M")
+	assert.EqualError(t, err, `no month elements allowed: "1M" at offset 1 (did you mean PT1M for minutes?)`)
+
+	_, err = Parse("P1M1D")
+	assert.EqualError(t, err, `no month elements allowed: "1M" at offset 1`)
+}
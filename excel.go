@@ -0,0 +1,30 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// ToExcelTime returns d as a spreadsheet serial time, the fraction of a day
+// used for time and duration cells by Excel, Google Sheets and LibreOffice
+// (e.g. 0.0625 for 1 hour 30 minutes). Format the cell as "[h]:mm:ss" to show
+// durations of a day or more.
+func ToExcelTime(d time.Duration) float64 {
+	return float64(d) / float64(dayTime)
+}
+
+// FromExcelTime converts a spreadsheet serial time, a fraction of a day, to a
+// time.Duration. Spreadsheets store times as binary fractions that do not
+// represent most times exactly, so the result is rounded to the nearest
+// millisecond, the precision spreadsheets display. Non-finite values fail
+// with ErrBadFormat and values that do not fit with ErrRange.
+func FromExcelTime(v float64) (time.Duration, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, ErrBadFormat
+	}
+	ms := math.Round(v * float64(dayTime/time.Millisecond))
+	if math.Abs(ms) > float64(math.MaxInt64/int64(time.Millisecond)) {
+		return 0, ErrRange
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,60 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToExcelTime(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out float64
+	}{
+		{90 * time.Minute, 0.0625},
+		{12 * time.Hour, 0.5},
+		{36 * time.Hour, 1.5},
+		{-6 * time.Hour, -0.25},
+		{0, 0},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, ToExcelTime(vec.in), vec.in)
+	}
+}
+
+func TestFromExcelTime(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  float64
+		out time.Duration
+		err error
+	}{
+		{0.0625, 90 * time.Minute, nil},
+		{1.5, 36 * time.Hour, nil},
+		{1.0 / 3, 8 * time.Hour, nil},
+		{0.041666678240740741, time.Hour + time.Millisecond, nil},
+		{-0.25, -6 * time.Hour, nil},
+		{0, 0, nil},
+		{math.NaN(), 0, ErrBadFormat},
+		{math.Inf(-1), 0, ErrBadFormat},
+		{200000, 0, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		d, err := FromExcelTime(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	for _, d := range []time.Duration{time.Second, 90 * time.Minute, 1234567 * time.Millisecond} {
+		v, err := FromExcelTime(ToExcelTime(d))
+		assert.NoError(t, err)
+		assert.Equal(t, d, v)
+	}
+}
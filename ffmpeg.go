@@ -0,0 +1,89 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ffmpegUnits maps the unit suffixes of FFmpeg durations to their lengths.
+var ffmpegUnits = map[string]time.Duration{
+	"s": time.Second, "ms": time.Millisecond, "us": time.Microsecond,
+}
+
+// ParseFFmpeg parses a duration in the syntax of FFmpeg options such as -t and
+// -ss. Durations are either "[-][HH:]MM:SS[.m...]", read as by ParseClock, such
+// as "01:30:05.5" or "02:33", or "[-]S+[.m...][s|ms|us]", a number of seconds
+// with an optional unit, such as "55", "0.2" or "200ms". Fractions use "."
+// only. Surrounding white space is ignored.
+func ParseFFmpeg(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "+,") {
+		return 0, ErrBadFormat
+	}
+	if strings.Contains(s, ":") {
+		return ParseClock(s)
+	}
+
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+
+	j := skipDigits(s, 0)
+	if j == 0 {
+		return 0, ErrBadFormat
+	}
+	if j < len(s) && s[j] == '.' {
+		k := skipDigits(s, j+1)
+		if k == j+1 {
+			return 0, ErrBadFormat
+		}
+		j = k
+	}
+	n, err := strconv.ParseFloat(s[:j], 64)
+	if err != nil {
+		return 0, ErrRange
+	}
+
+	t := time.Second
+	if unit := s[j:]; unit != "" {
+		var ok bool
+		if t, ok = ffmpegUnits[unit]; !ok {
+			return 0, ErrBadFormat
+		}
+	}
+
+	v := math.Round(n * float64(t))
+	if v >= math.MaxInt64 {
+		return 0, ErrRange
+	}
+	if neg {
+		v = -v
+	}
+	return time.Duration(v), nil
+}
+
+// FromFFmpeg converts an FFmpeg duration, as accepted by ParseFFmpeg, to
+// ISO8601, as written by FormatWithOptions with opts.
+func FromFFmpeg(s string, opts ...FormatOption) (string, error) {
+	d, err := ParseFFmpeg(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// ToFFmpeg converts an ISO8601 duration, as accepted by ParseWithOptions with
+// opts, to the "HH:MM:SS.mmm" form of FFmpeg durations as written by
+// FormatClock (e.g. "01:30:05.500" for "PT1H30M5.5S"), rounded to the nearest
+// millisecond. Pass AllowNegative to accept negative durations, which FFmpeg
+// writes with a leading "-".
+func ToFFmpeg(s string, opts ...ParseOption) (string, error) {
+	d, err := ParseWithOptions(s, opts...)
+	if err != nil {
+		return "", err
+	}
+	return FormatClock(d), nil
+}
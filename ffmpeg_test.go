@@ -0,0 +1,93 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFFmpeg(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"12:03:45", 12*time.Hour + 3*time.Minute + 45*time.Second, nil},
+		{"01:30:05.5", 90*time.Minute + 5500*time.Millisecond, nil},
+		{"23.189", 23189 * time.Millisecond, nil},
+		{"02:33", 2*time.Minute + 33*time.Second, nil},
+		{"-00:01:30", -90 * time.Second, nil},
+		{"55", 55 * time.Second, nil},
+		{"0.2", 200 * time.Millisecond, nil},
+		{"200ms", 200 * time.Millisecond, nil},
+		{"200000us", 200 * time.Millisecond, nil},
+		{"1.5s", 1500 * time.Millisecond, nil},
+		{"-5s", -5 * time.Second, nil},
+		{" 30 ", 30 * time.Second, nil},
+		{"0", 0, nil},
+
+		{"", 0, ErrBadFormat},
+		{"s", 0, ErrBadFormat},
+		{"-", 0, ErrBadFormat},
+		{".5", 0, ErrBadFormat},
+		{"5.s", 0, ErrBadFormat},
+		{"5 s", 0, ErrBadFormat},
+		{"5m", 0, ErrBadFormat},
+		{"5min", 0, ErrBadFormat},
+		{"+5", 0, ErrBadFormat},
+		{"1,5", 0, ErrBadFormat},
+		{"00:10,5", 0, ErrBadFormat},
+		{"PT5S", 0, ErrBadFormat},
+		{"02:60", 0, ErrRange},
+		{"10000000000000s", 0, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseFFmpeg(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestFromFFmpeg(t *testing.T) {
+	t.Parallel()
+
+	s, err := FromFFmpeg("01:30:05.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "PT1H30M5.500S", s)
+
+	s, err = FromFFmpeg("200ms", TrimZeros())
+	assert.NoError(t, err)
+	assert.Equal(t, "PT0.2S", s)
+
+	_, err = FromFFmpeg("5 minutes")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestToFFmpeg(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []ParseOption
+		out  string
+		err  error
+	}{
+		{"PT1H30M5.5S", nil, "01:30:05.500", nil},
+		{"P1DT2M", nil, "24:02:00", nil},
+		{"PT0.0005S", nil, "00:00:00.001", nil},
+		{"PT0S", nil, "00:00:00", nil},
+		{"-PT90S", []ParseOption{AllowNegative()}, "-00:01:30", nil},
+		{"-PT90S", nil, "", ErrBadFormat},
+		{"P1M", nil, "", ErrNoMonth},
+	}
+
+	for _, vec := range vecs {
+		s, err := ToFFmpeg(vec.in, vec.opts...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
@@ -0,0 +1,76 @@
+package duration
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// FixedSize is the size of the layout written by EncodeFixed.
+const FixedSize = 16
+
+// maxFixedMonths is the largest month count of the layout written by
+// EncodeFixed.
+const maxFixedMonths = 1<<24 - 1
+
+// EncodeFixed returns c in a fixed 16-byte layout that is simple to read and
+// write in any language, so that durations can be exchanged without parsing
+// strings. All counts are unsigned and big-endian:
+//
+//	offset  size  field
+//	0       1     sign: 0 for positive or zero, 1 for negative
+//	1       3     months
+//	4       4     days
+//	8       8     nanoseconds
+//
+// Conversion to months, days and nanoseconds is as by
+// Components.CassandraDuration, so the fields of c must share a sign, and the
+// resulting months and days must be whole numbers, otherwise ErrBadFormat is
+// returned. Months beyond 16,777,215 and other values that do not fit fail
+// with ErrRange.
+func EncodeFixed(c Components) ([]byte, error) {
+	v, err := c.CassandraDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	var sign byte
+	months, days, ns := int64(v.Months), int64(v.Days), uint64(v.Nanoseconds)
+	if months < 0 || days < 0 || v.Nanoseconds < 0 {
+		sign, months, days, ns = 1, -months, -days, -ns
+	}
+	if months > maxFixedMonths {
+		return nil, ErrRange
+	}
+
+	b := make([]byte, 0, FixedSize)
+	b = binary.BigEndian.AppendUint32(b, uint32(sign)<<24|uint32(months))
+	b = binary.BigEndian.AppendUint32(b, uint32(days))
+	return binary.BigEndian.AppendUint64(b, ns), nil
+}
+
+// DecodeFixed parses the 16-byte layout written by EncodeFixed. Months and
+// days are returned as stored; the nanoseconds are split into hours, minutes
+// and seconds as by ParsePostgres. Data of any other length, or with a sign
+// byte other than 0 or 1, fails with ErrBadFormat.
+func DecodeFixed(b []byte) (Components, error) {
+	if len(b) != FixedSize || b[0] > 1 {
+		return Components{}, ErrBadFormat
+	}
+
+	ns := binary.BigEndian.Uint64(b[8:])
+	c := Components{
+		Months:  float64(binary.BigEndian.Uint32(b) & maxFixedMonths),
+		Days:    float64(binary.BigEndian.Uint32(b[4:])),
+		Hours:   float64(ns / uint64(time.Hour)),
+		Minutes: float64(ns % uint64(time.Hour) / uint64(time.Minute)),
+		Seconds: time.Duration(ns % uint64(time.Minute)).Seconds(),
+	}
+	if b[0] == 1 {
+		for u := Year; u < numUnits; u++ {
+			if p := c.field(u); *p != 0 {
+				*p = -*p
+			}
+		}
+	}
+	return c, nil
+}
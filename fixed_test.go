@@ -0,0 +1,89 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeFixed(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out []byte
+		err error
+	}{
+		{
+			Components{Years: 1, Months: 2, Weeks: 1, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5},
+			[]byte{0, 0, 0, 14, 0, 0, 0, 10, 0, 0, 0x0d, 0x60, 0x1f, 0xb5, 0x59, 0x00},
+			nil,
+		},
+		{
+			Components{Months: -1, Days: -2, Seconds: -1},
+			[]byte{1, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0x00},
+			nil,
+		},
+		{
+			Components{Seconds: -9223372036.854775808},
+			[]byte{1, 0, 0, 0, 0, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0, 0},
+			nil,
+		},
+		{Components{Months: maxFixedMonths}, []byte{0, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, nil},
+		{Components{}, make([]byte, FixedSize), nil},
+		{Components{Months: maxFixedMonths + 1}, nil, ErrRange},
+		{Components{Months: 1, Days: -1}, nil, ErrBadFormat},
+		{Components{Days: 0.5}, nil, ErrBadFormat},
+		{Components{Seconds: math.NaN()}, nil, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		b, err := EncodeFixed(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.in)
+		assert.Equal(t, vec.out, b, "%+v", vec.in)
+	}
+}
+
+func TestDecodeFixed(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  []byte
+		out Components
+		err error
+	}{
+		{
+			[]byte{0, 0, 0, 14, 0, 0, 0, 10, 0, 0, 0x0d, 0x60, 0x1f, 0xb5, 0x59, 0x00},
+			Components{Months: 14, Days: 10, Hours: 4, Minutes: 5, Seconds: 6.5},
+			nil,
+		},
+		{
+			[]byte{1, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0x00},
+			Components{Months: -1, Days: -2, Seconds: -1},
+			nil,
+		},
+		{
+			[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			Components{Hours: 5124095, Minutes: 34, Seconds: 33.709551615},
+			nil,
+		},
+		{make([]byte, FixedSize), Components{}, nil},
+		{[]byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Components{}, ErrBadFormat},
+		{make([]byte, FixedSize-1), Components{}, ErrBadFormat},
+		{nil, Components{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		c, err := DecodeFixed(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%x", vec.in)
+		assert.Equal(t, vec.out, c, "%x", vec.in)
+	}
+
+	c := Components{Years: -2, Days: -3, Hours: -12}
+	b, err := EncodeFixed(c)
+	assert.NoError(t, err)
+	c, err = DecodeFixed(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "-P24M3DT12H", c.String())
+}
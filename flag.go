@@ -0,0 +1,19 @@
+package duration
+
+// Set implements flag.Value, so that a Duration can be used as a command-line
+// flag with flag.Var. It parses s as ISO8601, with a leading "-" permitted;
+// the flag package reports any error along with the flag name.
+func (d *Duration) Set(s string) error {
+	v, err := parseSigned(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Type returns the type name shown for Duration flags in the help output of
+// github.com/spf13/pflag, whose Value interface it completes.
+func (d *Duration) Type() string {
+	return "duration"
+}
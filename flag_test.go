@@ -0,0 +1,39 @@
+package duration
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ flag.Value = (*Duration)(nil)
+
+func TestDurationSet(t *testing.T) {
+	t.Parallel()
+
+	timeout := Duration(30 * time.Second)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&timeout, "timeout", "ISO 8601 duration")
+
+	assert.NoError(t, fs.Parse([]string{"-timeout", "PT1M30S"}))
+	assert.Equal(t, Duration(90*time.Second), timeout)
+
+	assert.NoError(t, fs.Parse([]string{"-timeout=-PT1S"}))
+	assert.Equal(t, Duration(-time.Second), timeout)
+
+	err := fs.Parse([]string{"-timeout", "90s"})
+	assert.EqualError(t, err, `invalid value "90s" for flag -timeout: bad format string`)
+
+	assert.Equal(t, "PT30S", fs.Lookup("timeout").DefValue)
+}
+
+func TestDurationType(t *testing.T) {
+	t.Parallel()
+
+	var d Duration
+	assert.Equal(t, "duration", d.Type())
+}
@@ -0,0 +1,26 @@
+package duration
+
+import "time"
+
+// FromGoString converts a duration in the syntax of time.ParseDuration (e.g.
+// "1h30m") to ISO8601 (e.g. "PT1H30M"), as written by FormatWithOptions with
+// opts. Strings that time.ParseDuration rejects fail with ErrBadFormat.
+func FromGoString(s string, opts ...FormatOption) (string, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return "", ErrBadFormat
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// ToGoString converts an ISO8601 duration, as accepted by ParseWithOptions
+// with opts, to the syntax of time.Duration.String (e.g. "1h30m0s" for
+// "PT1H30M"), which time.ParseDuration and tools built on it accept. Days,
+// weeks and years are carried into hours.
+func ToGoString(s string, opts ...ParseOption) (string, error) {
+	d, err := ParseWithOptions(s, opts...)
+	if err != nil {
+		return "", err
+	}
+	return d.String(), nil
+}
@@ -0,0 +1,82 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromGoString(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []FormatOption
+		out  string
+	}{
+		{"1h30m", nil, "PT1H30M"},
+		{"90m", nil, "PT1H30M"},
+		{"36h", nil, "P1DT12H"},
+		{"36h", []FormatOption{LargestUnit(Hour)}, "PT36H"},
+		{"1.5s", nil, "PT1.500S"},
+		{"250ms", []FormatOption{TrimZeros()}, "PT0.25S"},
+		{"0s", nil, "P0Y"},
+		{"0", []FormatOption{ZeroAs(ZeroSeconds)}, "PT0S"},
+		{"-1h", []FormatOption{AllowNegative()}, "-PT1H"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FromGoString(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	for _, in := range []string{"", "1x", "PT1H", "1h30"} {
+		_, err := FromGoString(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+	_, err := FromGoString("-1h")
+	assert.ErrorIs(t, err, ErrNoNegative)
+}
+
+func TestToGoString(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []ParseOption
+		out  string
+	}{
+		{"PT1H30M", nil, "1h30m0s"},
+		{"P1DT12H", nil, "36h0m0s"},
+		{"P1W", nil, "168h0m0s"},
+		{"PT1.5S", nil, "1.5s"},
+		{"PT0.001S", nil, "1ms"},
+		{"P0Y", nil, "0s"},
+		{"-PT1H", []ParseOption{AllowNegative()}, "-1h0m0s"},
+	}
+
+	for _, vec := range vecs {
+		s, err := ToGoString(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	_, err := ToGoString("1h30m")
+	assert.ErrorIs(t, err, ErrBadFormat)
+	_, err = ToGoString("P1M")
+	assert.ErrorIs(t, err, ErrNoMonth)
+}
+
+func TestGoStringRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"PT1H30M", "P1DT12H", "PT0.001S", "P0Y"} {
+		g, err := ToGoString(in)
+		assert.NoError(t, err, in)
+
+		s, err := FromGoString(g)
+		assert.NoError(t, err, in)
+		assert.Equal(t, in, s)
+	}
+}
@@ -0,0 +1,53 @@
+package duration
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// MarshalGQL implements the Marshaler interface of
+// github.com/99designs/gqlgen, writing d as an ISO8601 string. To expose
+// durations as a custom scalar, declare it in the schema (e.g. "scalar
+// ISODuration") and bind it to this type in gqlgen.yml:
+//
+//	models:
+//	  ISODuration:
+//	    model: github.com/SpirentOrion/iso8601duration.v2.Duration
+func (d Duration) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(d.String()))
+}
+
+// UnmarshalGQL implements the Unmarshaler interface of
+// github.com/99designs/gqlgen. It accepts an ISO8601 string, or a number of
+// seconds as UnmarshalJSON does. Other values fail with ErrBadFormat.
+func (d *Duration) UnmarshalGQL(v any) error {
+	var (
+		r   time.Duration
+		err error
+	)
+	switch v := v.(type) {
+	case string:
+		r, err = parseSigned(v)
+	case int:
+		r, err = fromSeconds(float64(v))
+	case int64:
+		r, err = fromSeconds(float64(v))
+	case float64:
+		r, err = fromSeconds(v)
+	case json.Number:
+		var f float64
+		if f, err = v.Float64(); err != nil {
+			return ErrBadFormat
+		}
+		r, err = fromSeconds(f)
+	default:
+		return ErrBadFormat
+	}
+	if err != nil {
+		return err
+	}
+	*d = Duration(r)
+	return nil
+}
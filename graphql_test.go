@@ -0,0 +1,60 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationMarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Duration
+		out string
+	}{
+		{Duration(90 * time.Minute), `"PT1H30M"`},
+		{Duration(-time.Second), `"-PT1S"`},
+		{0, `"P0Y"`},
+	}
+
+	for _, vec := range vecs {
+		var b bytes.Buffer
+		vec.in.MarshalGQL(&b)
+		assert.Equal(t, vec.out, b.String())
+	}
+}
+
+func TestDurationUnmarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  any
+		out Duration
+		err error
+	}{
+		{"PT1H30M", Duration(90 * time.Minute), nil},
+		{"-PT1S", Duration(-time.Second), nil},
+		{90, Duration(90 * time.Second), nil},
+		{int64(5400), Duration(90 * time.Minute), nil},
+		{0.25, Duration(250 * time.Millisecond), nil},
+		{json.Number("1.5"), Duration(1500 * time.Millisecond), nil},
+		{"1h30m", 0, ErrBadFormat},
+		{"P1M", 0, ErrNoMonth},
+		{json.Number("x"), 0, ErrBadFormat},
+		{math.Inf(1), 0, ErrRange},
+		{true, 0, ErrBadFormat},
+		{nil, 0, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		var d Duration
+		err := d.UnmarshalGQL(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%v", vec.in)
+		assert.Equal(t, vec.out, d, "%v", vec.in)
+	}
+}
@@ -0,0 +1,164 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// htmlSpace is the ASCII white space of the HTML standard.
+const htmlSpace = " \t\n\f\r"
+
+// htmlUnits are the unit letters of HTML duration strings, largest first.
+const htmlUnits = "wdhms"
+
+var htmlUnitTimes = [...]time.Duration{weekTime, dayTime, time.Hour, time.Minute, time.Second}
+
+// ParseHTMLDuration parses a duration string as accepted by the datetime
+// attribute of the HTML <time> element. The standard allows two forms: a
+// restricted ISO8601 duration with only days, hours, minutes and seconds
+// (e.g. "P1DT2H30M"), and a list of components, each a number and a unit of
+// "w", "d", "h", "m" or "s", in any order and optionally separated by white
+// space (e.g. "1h 30m 5s"). Unit letters may be in either case, and only
+// seconds may have a fraction, of one to three digits. A unit repeated in
+// the second form fails with ErrDuplicate; other invalid strings fail with
+// ErrBadFormat. Surrounding white space is ignored.
+func ParseHTMLDuration(s string) (time.Duration, error) {
+	s = strings.Trim(s, htmlSpace)
+	if s == "" {
+		return 0, ErrBadFormat
+	}
+	if s[0] == 'P' || s[0] == 'p' {
+		return parseHTMLISO(s[1:])
+	}
+
+	var d time.Duration
+	var seen [len(htmlUnits)]bool
+	for s != "" {
+		n, frac, rest, err := parseHTMLNumber(s)
+		if err != nil {
+			return 0, err
+		}
+		s = strings.TrimLeft(rest, htmlSpace)
+
+		u := -1
+		if s != "" {
+			u = strings.IndexByte(htmlUnits, s[0]|0x20)
+		}
+		switch {
+		case u == -1 || frac != "" && htmlUnits[u] != 's':
+			return 0, ErrBadFormat
+		case seen[u]:
+			return 0, ErrDuplicate
+		}
+		seen[u] = true
+		s = strings.TrimLeft(s[1:], htmlSpace)
+
+		if d, err = addHTMLElement(d, n, frac, u); err != nil {
+			return 0, err
+		}
+	}
+	return d, nil
+}
+
+// FromHTMLDuration converts an HTML duration string, as accepted by
+// ParseHTMLDuration, to ISO8601, as written by FormatWithOptions with opts,
+// e.g. "PT1H30M5S" for "1h 30m 5s".
+func FromHTMLDuration(s string, opts ...FormatOption) (string, error) {
+	d, err := ParseHTMLDuration(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// parseHTMLISO parses the ISO8601 form of an HTML duration string, after the
+// "P".
+func parseHTMLISO(s string) (time.Duration, error) {
+	date, tm, hasTime := s, "", false
+	if i := strings.IndexAny(s, "Tt"); i != -1 {
+		date, tm, hasTime = s[:i], s[i+1:], true
+	}
+	if date == "" && tm == "" {
+		return 0, ErrBadFormat
+	}
+
+	var d time.Duration
+	if date != "" {
+		n, frac, rest, err := parseHTMLNumber(date)
+		if err != nil {
+			return 0, err
+		}
+		if frac != "" || (rest != "D" && rest != "d") {
+			return 0, ErrBadFormat
+		}
+		if d, err = addHTMLElement(d, n, "", 1); err != nil {
+			return 0, err
+		}
+	}
+	if hasTime && tm == "" {
+		return 0, ErrBadFormat
+	}
+
+	next := 2
+	for tm != "" {
+		n, frac, rest, err := parseHTMLNumber(tm)
+		if err != nil {
+			return 0, err
+		}
+
+		u := -1
+		if rest != "" {
+			u = strings.IndexByte(htmlUnits, rest[0]|0x20)
+		}
+		if u < next || frac != "" && htmlUnits[u] != 's' {
+			return 0, ErrBadFormat
+		}
+		next = u + 1
+		tm = rest[1:]
+
+		if d, err = addHTMLElement(d, n, frac, u); err != nil {
+			return 0, err
+		}
+	}
+	return d, nil
+}
+
+// parseHTMLNumber splits the number at the start of s into its digits and
+// fraction, which has one to three digits if present.
+func parseHTMLNumber(s string) (n, frac, rest string, err error) {
+	i := skipDigits(s, 0)
+	if i == 0 {
+		return "", "", "", ErrBadFormat
+	}
+	n, rest = s[:i], s[i:]
+	if strings.HasPrefix(rest, ".") {
+		j := skipDigits(rest, 1)
+		if j == 1 || j > 4 {
+			return "", "", "", ErrBadFormat
+		}
+		frac, rest = rest[1:j], rest[j:]
+	}
+	return n, frac, rest, nil
+}
+
+// addHTMLElement adds n.frac of unit htmlUnits[u] to d.
+func addHTMLElement(d time.Duration, n, frac string, u int) (time.Duration, error) {
+	v, err := strconv.ParseInt(n, 10, 64)
+	t := htmlUnitTimes[u]
+	if err != nil || v > int64((math.MaxInt64-d)/t) {
+		return 0, ErrRange
+	}
+	d += time.Duration(v) * t
+
+	if frac != "" {
+		ms, _ := strconv.Atoi((frac + "00")[:3])
+		f := time.Duration(ms) * time.Millisecond
+		if f > math.MaxInt64-d {
+			return 0, ErrRange
+		}
+		d += f
+	}
+	return d, nil
+}
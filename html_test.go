@@ -0,0 +1,76 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHTMLDuration(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"PT4H18M3S", 4*time.Hour + 18*time.Minute + 3*time.Second, nil},
+		{"P1DT2H", 26 * time.Hour, nil},
+		{"P2D", 2 * dayTime, nil},
+		{"PT1.5S", 1500 * time.Millisecond, nil},
+		{"PT0.001S", time.Millisecond, nil},
+		{"pt1h1s", time.Hour + time.Second, nil},
+		{"4h 18m 3s", 4*time.Hour + 18*time.Minute + 3*time.Second, nil},
+		{"4h18m3s", 4*time.Hour + 18*time.Minute + 3*time.Second, nil},
+		{"3s 18m 4h", 4*time.Hour + 18*time.Minute + 3*time.Second, nil},
+		{"1w 2d", 9 * dayTime, nil},
+		{"4 H 3.25 S", 4*time.Hour + 3250*time.Millisecond, nil},
+		{" 90m\n", 90 * time.Minute, nil},
+
+		{"", 0, ErrBadFormat},
+		{"P", 0, ErrBadFormat},
+		{"PT", 0, ErrBadFormat},
+		{"P1DT", 0, ErrBadFormat},
+		{"P1Y", 0, ErrBadFormat},
+		{"P1M", 0, ErrBadFormat},
+		{"P1W", 0, ErrBadFormat},
+		{"P1.5D", 0, ErrBadFormat},
+		{"PT1.5H", 0, ErrBadFormat},
+		{"PT1S1M", 0, ErrBadFormat},
+		{"PT1H1H", 0, ErrBadFormat},
+		{"PT1.0001S", 0, ErrBadFormat},
+		{"PT1,5S", 0, ErrBadFormat},
+		{"-PT1S", 0, ErrBadFormat},
+		{"P1D T1H", 0, ErrBadFormat},
+		{"1.5h", 0, ErrBadFormat},
+		{"1.s", 0, ErrBadFormat},
+		{"4", 0, ErrBadFormat},
+		{"h", 0, ErrBadFormat},
+		{"1y", 0, ErrBadFormat},
+		{"1h 2h", 0, ErrDuplicate},
+		{"99999999999w", 0, ErrRange},
+		{"PT99999999999999999999S", 0, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseHTMLDuration(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestFromHTMLDuration(t *testing.T) {
+	t.Parallel()
+
+	s, err := FromHTMLDuration("4h 18m 3s")
+	assert.NoError(t, err)
+	assert.Equal(t, "PT4H18M3S", s)
+
+	s, err = FromHTMLDuration("1w", UseWeeks())
+	assert.NoError(t, err)
+	assert.Equal(t, "P1W", s)
+
+	_, err = FromHTMLDuration("4 hours")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
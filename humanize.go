@@ -0,0 +1,135 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// A Part is a single element of a humanized duration, such as "30 minutes".
+type Part struct {
+	Unit Unit
+
+	// Value is the amount of Unit as a decimal number, e.g. "1.5".
+	Value string
+}
+
+// Parts splits d into the elements described by Humanize, most significant
+// first. It accepts the same options as Humanize, except that values are
+// always written with a "." whatever DecimalSeparator says. The sign of d is
+// ignored. Parts is useful for building humanized output in other forms or
+// languages.
+func Parts(d time.Duration, opts ...FormatOption) []Part {
+	c := newFormatConfig(opts)
+	if d < 0 {
+		d = -d
+	}
+
+	if c.single || c.maxComponents > 0 {
+		r := c.resolve(d)
+		c = &r
+	}
+	c.sep = SeparatorDot
+	d, _ = c.round(d)
+
+	var parts []Part
+	for _, u := range formatUnits {
+		if u < c.largest {
+			continue
+		}
+
+		t := unitTimes[u]
+		if u < c.smallest && d < t {
+			continue
+		}
+
+		if u == c.smallest {
+			parts = append(parts, Part{u, string(c.appendDecimal(nil, d, u, true))})
+			break
+		}
+
+		parts = append(parts, Part{u, string(c.appendDecimal(nil, d/t*t, u, true))})
+		if d %= t; d == 0 {
+			break
+		}
+	}
+	return parts
+}
+
+// Humanize returns an English description of d such as "1 hour, 30 minutes".
+// It accepts the same options as FormatWithOptions; LargestUnit, SmallestUnit,
+// Rounding, Precision, MaxComponents and SingleUnit control which units are
+// shown and how precisely, and DecimalSeparator how fractions are written.
+// Fractions are written without trailing zeros and negative durations are
+// prefixed with "-".
+func Humanize(d time.Duration, opts ...FormatOption) string {
+	c := newFormatConfig(opts)
+
+	var s strings.Builder
+	if d < 0 {
+		s.WriteByte('-')
+	}
+
+	for i, p := range Parts(d, opts...) {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(c.value(p))
+		s.WriteByte(' ')
+		s.WriteString(p.Unit.String())
+		if p.Value != "1" {
+			s.WriteByte('s')
+		}
+	}
+	return s.String()
+}
+
+// compactLabels are the default unit labels used by HumanizeCompact.
+var compactLabels = map[Unit]string{
+	Year:   "y",
+	Month:  "mo",
+	Week:   "w",
+	Day:    "d",
+	Hour:   "h",
+	Minute: "m",
+	Second: "s",
+}
+
+// HumanizeCompact returns a short description of d such as "1h 30m" or
+// "2d 4h", for dense tables and command-line output. It accepts the same
+// options as Humanize; UnitLabels replaces the default unit abbreviations.
+func HumanizeCompact(d time.Duration, opts ...FormatOption) string {
+	c := newFormatConfig(opts)
+
+	var s strings.Builder
+	if d < 0 {
+		s.WriteByte('-')
+	}
+
+	for i, p := range Parts(d, opts...) {
+		if i > 0 {
+			s.WriteByte(' ')
+		}
+		s.WriteString(c.value(p))
+		if label, ok := c.labels[p.Unit]; ok {
+			s.WriteString(label)
+		} else {
+			s.WriteString(compactLabels[p.Unit])
+		}
+	}
+	return s.String()
+}
+
+// value returns the value of p with the decimal separator of c.
+func (c *formatConfig) value(p Part) string {
+	return strings.Replace(p.Value, ".", string(c.separator()), 1)
+}
+
+// HumanizeString parses s with Parse and returns its English description as
+// from Humanize.
+func HumanizeString(s string, opts ...FormatOption) (string, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return Humanize(d, opts...), nil
+}
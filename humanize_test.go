@@ -0,0 +1,88 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{0, nil, "0 seconds"},
+		{time.Second, nil, "1 second"},
+		{1500 * time.Millisecond, nil, "1.5 seconds"},
+		{90 * time.Minute, nil, "1 hour, 30 minutes"},
+		{-90 * time.Minute, nil, "-1 hour, 30 minutes"},
+		{yearTime + 2*dayTime + time.Second, nil, "1 year, 2 days, 1 second"},
+		{2*time.Hour + time.Minute + time.Millisecond, nil, "2 hours, 1 minute, 0.001 seconds"},
+
+		{90 * time.Minute, []FormatOption{MaxComponents(1)}, "1.5 hours"},
+		{90*time.Minute + 10*time.Second, []FormatOption{MaxComponents(1), Precision(2)}, "1.5 hours"},
+		{100 * time.Minute, []FormatOption{MaxComponents(1), Rounding(RoundHalfExpand)}, "2 hours"},
+		{time.Hour + time.Second, []FormatOption{MaxComponents(2)}, "1 hour, 1 second"},
+		{dayTime + 90*time.Minute + 30*time.Second, []FormatOption{MaxComponents(2), Rounding(RoundTrunc)}, "1 day, 1 hour"},
+		{59*time.Minute + 59*time.Second, []FormatOption{MaxComponents(1), Rounding(RoundHalfExpand)}, "1 hour"},
+
+		{90*time.Minute + 20*time.Second, []FormatOption{SmallestUnit(Minute), Rounding(RoundHalfExpand)}, "1 hour, 30 minutes"},
+		{20 * time.Second, []FormatOption{SmallestUnit(Minute), Rounding(RoundHalfExpand)}, "0 minutes"},
+		{36 * time.Hour, []FormatOption{LargestUnit(Hour)}, "36 hours"},
+		{36 * time.Hour, []FormatOption{SingleUnit()}, "1.5 days"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, Humanize(vec.in, vec.opts...), vec.in)
+	}
+}
+
+func TestHumanizeString(t *testing.T) {
+	t.Parallel()
+
+	s, err := HumanizeString("PT1H30M")
+	assert.NoError(t, err)
+	assert.Equal(t, "1 hour, 30 minutes", s)
+
+	_, err = HumanizeString("P1M")
+	assert.ErrorIs(t, err, ErrNoMonth)
+}
+
+func TestParts(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []Part{{Hour, "1"}, {Minute, "30"}}, Parts(-90*time.Minute))
+	assert.Equal(t, []Part{{Second, "0"}}, Parts(0))
+	assert.Equal(t, []Part{{Hour, "1.5"}}, Parts(90*time.Minute, SingleUnit(), DecimalSeparator(SeparatorComma)))
+	assert.Equal(t, "1,5 hours", Humanize(90*time.Minute, SingleUnit(), DecimalSeparator(SeparatorComma)))
+	assert.Equal(t, "1,5h", HumanizeCompact(90*time.Minute, SingleUnit(), DecimalSeparator(SeparatorComma)))
+}
+
+func TestHumanizeCompact(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{90 * time.Minute, nil, "1h 30m"},
+		{2*dayTime + 4*time.Hour, nil, "2d 4h"},
+		{yearTime + time.Second, nil, "1y 1s"},
+		{1500 * time.Millisecond, nil, "1.5s"},
+		{-time.Minute, nil, "-1m"},
+		{0, nil, "0s"},
+
+		{90 * time.Minute, []FormatOption{LargestUnit(Minute)}, "90m"},
+		{90 * time.Minute, []FormatOption{UnitLabels(map[Unit]string{Minute: "min"})}, "1h 30min"},
+		{2*dayTime + 4*time.Hour + 5*time.Minute, []FormatOption{MaxComponents(2), Rounding(RoundTrunc)}, "2d 4h"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, HumanizeCompact(vec.in, vec.opts...), vec.in)
+	}
+}
@@ -0,0 +1,161 @@
+// Package i18n provides localized humanization of durations, building on the
+// parts produced by the parent duration package and the plural rules in
+// golang.org/x/text.
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// unitNames holds the name of each unit for the plural forms a language uses.
+// Forms missing from a map fall back to plural.Other.
+type unitNames [duration.Second + 1]map[plural.Form]string
+
+type locale struct {
+	tag     language.Tag
+	decimal string // decimal separator
+	space   string // between a number and its unit
+	sep     string // between parts
+	units   unitNames
+}
+
+var locales = []locale{
+	{language.English, ".", " ", ", ", unitNames{
+		{plural.One: "year", plural.Other: "years"},
+		{plural.One: "month", plural.Other: "months"},
+		{plural.One: "week", plural.Other: "weeks"},
+		{plural.One: "day", plural.Other: "days"},
+		{plural.One: "hour", plural.Other: "hours"},
+		{plural.One: "minute", plural.Other: "minutes"},
+		{plural.One: "second", plural.Other: "seconds"},
+	}},
+	{language.German, ",", " ", ", ", unitNames{
+		{plural.One: "Jahr", plural.Other: "Jahre"},
+		{plural.One: "Monat", plural.Other: "Monate"},
+		{plural.One: "Woche", plural.Other: "Wochen"},
+		{plural.One: "Tag", plural.Other: "Tage"},
+		{plural.One: "Stunde", plural.Other: "Stunden"},
+		{plural.One: "Minute", plural.Other: "Minuten"},
+		{plural.One: "Sekunde", plural.Other: "Sekunden"},
+	}},
+	{language.French, ",", " ", ", ", unitNames{
+		{plural.One: "an", plural.Other: "ans"},
+		{plural.One: "mois", plural.Other: "mois"},
+		{plural.One: "semaine", plural.Other: "semaines"},
+		{plural.One: "jour", plural.Other: "jours"},
+		{plural.One: "heure", plural.Other: "heures"},
+		{plural.One: "minute", plural.Other: "minutes"},
+		{plural.One: "seconde", plural.Other: "secondes"},
+	}},
+	{language.Spanish, ",", " ", ", ", unitNames{
+		{plural.One: "año", plural.Other: "años"},
+		{plural.One: "mes", plural.Other: "meses"},
+		{plural.One: "semana", plural.Other: "semanas"},
+		{plural.One: "día", plural.Other: "días"},
+		{plural.One: "hora", plural.Other: "horas"},
+		{plural.One: "minuto", plural.Other: "minutos"},
+		{plural.One: "segundo", plural.Other: "segundos"},
+	}},
+	{language.Russian, ",", " ", ", ", unitNames{
+		{plural.One: "год", plural.Few: "года", plural.Many: "лет", plural.Other: "года"},
+		{plural.One: "месяц", plural.Few: "месяца", plural.Many: "месяцев", plural.Other: "месяца"},
+		{plural.One: "неделя", plural.Few: "недели", plural.Many: "недель", plural.Other: "недели"},
+		{plural.One: "день", plural.Few: "дня", plural.Many: "дней", plural.Other: "дня"},
+		{plural.One: "час", plural.Few: "часа", plural.Many: "часов", plural.Other: "часа"},
+		{plural.One: "минута", plural.Few: "минуты", plural.Many: "минут", plural.Other: "минуты"},
+		{plural.One: "секунда", plural.Few: "секунды", plural.Many: "секунд", plural.Other: "секунды"},
+	}},
+	{language.Polish, ",", " ", ", ", unitNames{
+		{plural.One: "rok", plural.Few: "lata", plural.Many: "lat", plural.Other: "roku"},
+		{plural.One: "miesiąc", plural.Few: "miesiące", plural.Many: "miesięcy", plural.Other: "miesiąca"},
+		{plural.One: "tydzień", plural.Few: "tygodnie", plural.Many: "tygodni", plural.Other: "tygodnia"},
+		{plural.One: "dzień", plural.Few: "dni", plural.Many: "dni", plural.Other: "dnia"},
+		{plural.One: "godzina", plural.Few: "godziny", plural.Many: "godzin", plural.Other: "godziny"},
+		{plural.One: "minuta", plural.Few: "minuty", plural.Many: "minut", plural.Other: "minuty"},
+		{plural.One: "sekunda", plural.Few: "sekundy", plural.Many: "sekund", plural.Other: "sekundy"},
+	}},
+	{language.Japanese, ".", "", "", unitNames{
+		{plural.Other: "年"},
+		{plural.Other: "か月"},
+		{plural.Other: "週間"},
+		{plural.Other: "日"},
+		{plural.Other: "時間"},
+		{plural.Other: "分"},
+		{plural.Other: "秒"},
+	}},
+}
+
+var matcher = func() language.Matcher {
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// Supported returns the languages HumanizeIn has translations for. Other
+// languages fall back to the closest supported one, or English.
+func Supported() []language.Tag {
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// HumanizeIn is like duration.Humanize but describes d in the language tag,
+// e.g. "1 Stunde, 30 Minuten" for language.German. Unit names follow the
+// language's plural rules, and fractions its decimal separator rather than
+// the one set by duration.DecimalSeparator.
+func HumanizeIn(d time.Duration, tag language.Tag, opts ...duration.FormatOption) string {
+	_, i, _ := matcher.Match(tag)
+	l := &locales[i]
+
+	var s strings.Builder
+	if d < 0 {
+		s.WriteByte('-')
+	}
+
+	for i, p := range duration.Parts(d, opts...) {
+		if i > 0 {
+			s.WriteString(l.sep)
+		}
+		s.WriteString(strings.Replace(p.Value, ".", l.decimal, 1))
+		s.WriteString(l.space)
+		s.WriteString(l.unitName(p))
+	}
+	return s.String()
+}
+
+// HumanizeStringIn parses s with duration.Parse and returns its description
+// in the language tag as from HumanizeIn.
+func HumanizeStringIn(s string, tag language.Tag, opts ...duration.FormatOption) (string, error) {
+	d, err := duration.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return HumanizeIn(d, tag, opts...), nil
+}
+
+func (l *locale) unitName(p duration.Part) string {
+	names := l.units[p.Unit]
+	if name, ok := names[pluralForm(l.tag, p.Value)]; ok {
+		return name
+	}
+	return names[plural.Other]
+}
+
+// pluralForm returns the cardinal plural form of the decimal number value,
+// which has no trailing zeros in its fraction.
+func pluralForm(tag language.Tag, value string) plural.Form {
+	whole, frac, _ := strings.Cut(value, ".")
+	i, _ := strconv.Atoi(whole)
+	f, _ := strconv.Atoi(frac)
+	return plural.Cardinal.MatchPlural(tag, i, len(frac), len(frac), f, f)
+}
@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestHumanizeIn(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		tag  language.Tag
+		opts []duration.FormatOption
+		out  string
+	}{
+		{90 * time.Minute, language.English, nil, "1 hour, 30 minutes"},
+		{90 * time.Minute, language.German, nil, "1 Stunde, 30 Minuten"},
+		{90 * time.Minute, language.MustParse("de-AT"), nil, "1 Stunde, 30 Minuten"},
+		{90 * time.Minute, language.German, []duration.FormatOption{duration.SingleUnit()}, "1,5 Stunden"},
+		{90 * time.Minute, language.Japanese, nil, "1時間30分"},
+		{-time.Second, language.Spanish, nil, "-1 segundo"},
+
+		// French treats zero and fractions below two as singular
+		{90 * time.Minute, language.French, []duration.FormatOption{duration.SingleUnit()}, "1,5 heure"},
+		{0, language.French, nil, "0 seconde"},
+
+		// Slavic languages distinguish few and many
+		{2 * time.Hour, language.Russian, nil, "2 часа"},
+		{5 * time.Hour, language.Russian, nil, "5 часов"},
+		{21 * time.Hour, language.Russian, nil, "21 час"},
+		{22 * time.Minute, language.Polish, nil, "22 minuty"},
+		{25 * time.Minute, language.Polish, nil, "25 minut"},
+		{1500 * time.Millisecond, language.Polish, nil, "1,5 sekundy"},
+		{1500 * time.Millisecond, language.Polish, []duration.FormatOption{duration.DecimalSeparator(duration.SeparatorComma)}, "1,5 sekundy"},
+		{1500 * time.Millisecond, language.English, []duration.FormatOption{duration.DecimalSeparator(duration.SeparatorComma)}, "1.5 seconds"},
+
+		// Unsupported languages fall back to English
+		{time.Hour, language.Swahili, nil, "1 hour"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, HumanizeIn(vec.in, vec.tag, vec.opts...), vec.tag.String())
+	}
+}
+
+func TestHumanizeStringIn(t *testing.T) {
+	t.Parallel()
+
+	s, err := HumanizeStringIn("P2D", language.German)
+	assert.NoError(t, err)
+	assert.Equal(t, "2 Tage", s)
+
+	_, err = HumanizeStringIn("P1M", language.German)
+	assert.ErrorIs(t, err, duration.ErrNoMonth)
+}
+
+func TestSupported(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, Supported(), language.German)
+	assert.Equal(t, language.English, Supported()[0])
+}
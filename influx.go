@@ -0,0 +1,79 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// influxUnits are the units of InfluxDB and Flux durations, largest first.
+// Weeks are only written when they divide the duration exactly; months ("mo")
+// are calendar units that a time.Duration cannot hold.
+var influxUnits = []labelUnit{
+	{"y", yearTime, true},
+	{"mo", 0, false},
+	{"w", weekTime, true},
+	{"d", dayTime, false},
+	{"h", time.Hour, false},
+	{"m", time.Minute, false},
+	{"s", time.Second, false},
+	{"ms", time.Millisecond, false},
+	{"us", time.Microsecond, false},
+	{"µs", time.Microsecond, false},
+	{"u", time.Microsecond, false},
+	{"µ", time.Microsecond, false},
+	{"ns", time.Nanosecond, false},
+}
+
+// FromInflux converts a duration literal of InfluxQL or Flux (e.g. "1h30m",
+// "7d", "52w" or "-1h") to ISO8601, as written by FormatWithOptions with opts;
+// pass AllowNegative to convert negative literals. Units must appear at most
+// once and largest first, and values must be whole numbers; other strings fail
+// with ErrBadFormat. A year is 365 days as in Parse, months fail with
+// ErrNoMonth, and the "INF" duration of InfluxQL retention policies fails with
+// ErrRange.
+func FromInflux(s string, opts ...FormatOption) (string, error) {
+	d, err := parseInflux(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// ToInflux converts an ISO8601 duration, as accepted by ParseWithOptions with
+// opts, to a duration literal accepted by both InfluxQL and Flux (e.g. "1h30m"
+// for "PT1H30M" or "52w" for "P364D"). Weeks are used only when they divide
+// the duration exactly, and years never are, as InfluxQL lacks them. Negative
+// durations are written with a leading "-", as Flux accepts.
+func ToInflux(s string, opts ...ParseOption) (string, error) {
+	d, err := ParseWithOptions(s, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	var b []byte
+	if d < 0 {
+		if d == math.MinInt64 {
+			return "", ErrRange
+		}
+		b = append(b, '-')
+		d = -d
+	}
+	return string(appendLabelUnits(b, d, influxUnits[2:])), nil
+}
+
+// parseInflux parses a duration literal of InfluxQL or Flux.
+func parseInflux(s string) (time.Duration, error) {
+	if s == "INF" || s == "inf" {
+		return 0, ErrRange
+	}
+
+	neg := len(s) > 0 && s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	d, err := parseLabelUnits(s, influxUnits)
+	if neg {
+		d = -d
+	}
+	return d, err
+}
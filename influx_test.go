@@ -0,0 +1,94 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromInflux(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []FormatOption
+		out  string
+	}{
+		{"1h30m", nil, "PT1H30M"},
+		{"7d", nil, "P7D"},
+		{"52w", nil, "P364D"},
+		{"52w", []FormatOption{UseWeeks()}, "P52W"},
+		{"1y", nil, "P1Y"},
+		{"1w2d3h4m5s6ms7us8ns", nil, "P9DT3H4M5.006007008S"},
+		{"5µs", nil, "PT0.000005S"},
+		{"5u", nil, "PT0.000005S"},
+		{"1m30s", nil, "PT1M30S"},
+		{"0s", nil, "P0Y"},
+		{"-1h", []FormatOption{AllowNegative()}, "-PT1H"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FromInflux(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"-", ErrBadFormat},
+		{"0", ErrBadFormat},
+		{"1.5h", ErrBadFormat},
+		{"30m1h", ErrBadFormat},
+		{"1h1h", ErrBadFormat},
+		{"1us1µs", ErrBadFormat},
+		{"1x", ErrBadFormat},
+		{"PT1H", ErrBadFormat},
+		{"-1h", ErrNoNegative},
+		{"1mo", ErrNoMonth},
+		{"INF", ErrRange},
+		{"300y", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := FromInflux(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestToInflux(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out string
+	}{
+		{"PT1H30M", "1h30m"},
+		{"P7D", "1w"},
+		{"P364D", "52w"},
+		{"P90D", "90d"},
+		{"P1Y", "365d"},
+		{"PT6.007000008S", "6s7ms8ns"},
+		{"PT0.000005S", "5us"},
+		{"P0Y", "0s"},
+		{"-PT1H", "-1h"},
+	}
+
+	for _, vec := range vecs {
+		s, err := ToInflux(vec.in, AllowNegative())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		d, err := parseInflux(s)
+		assert.NoError(t, err, s)
+		d0, _ := ParseWithOptions(vec.in, AllowNegative())
+		assert.Equal(t, d0, d, vec.in)
+	}
+
+	_, err := ToInflux("1h")
+	assert.ErrorIs(t, err, ErrBadFormat)
+	_, err = ToInflux("-PT1H")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
@@ -0,0 +1,381 @@
+package duration
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// intervalLayouts are the layouts of the times in an interval, in the extended
+// and basic formats of ISO8601. Times without a UTC offset are taken as UTC.
+// Fractions of a second are accepted after the seconds of any layout.
+var intervalLayouts = [...]string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"20060102T150405Z0700",
+	"20060102T150405",
+	"20060102T1504Z0700",
+	"20060102T1504",
+	"20060102",
+}
+
+// An Interval is an ISO8601 time interval, the span of time between two
+// instants. A zero Start or End is an open end, with no bound on that side,
+// as written ".." by ISO 8601-2.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+
+	// Period is the duration of an interval written in the start/duration
+	// or duration/end form, with its elements as written, from which the
+	// other end was computed. It is zero for the start/end form.
+	Period Components
+}
+
+// ParseInterval parses an ISO8601 time interval written in one of three
+// forms, separated by "/":
+//
+//   - a start and end, such as "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+//     where the end may leave out leading elements that are the same as the
+//     start's, such as "2007-12-14T13:30Z/15:30", in which case it shares
+//     the start's UTC offset;
+//   - a start and duration, such as "2007-03-01T13:00:00Z/P1Y2M10DT2H30M";
+//   - a duration and end, such as "P1Y2M10DT2H30M/2008-05-11T15:30:00Z".
+//
+// Either end of the first form may be "..", for an open end as in ISO 8601-2
+// (e.g. "2020-01-01/.." or "../2020-01-01"), which is left zero.
+//
+// Times are in the extended or basic format, with a UTC offset or "Z", to the
+// second with an optional fraction, to the minute, or a date alone; times
+// without an offset are taken as UTC. Durations are read as by
+// ParseComponents, and the missing end is computed from the other with
+// Components.AddTo, in the UTC offset of that end. An end before the start
+// fails with ErrRange, as does an end at the zero time.Time, which would be
+// taken for an open end, and other invalid intervals fail with ErrBadFormat.
+// Surrounding white space is ignored. Options may restrict the intervals
+// accepted further.
+func ParseInterval(s string, opts ...IntervalOption) (Interval, error) {
+	c := newIntervalConfig(opts)
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Interval{}, ErrBadFormat
+	}
+
+	var i Interval
+	var err error
+	switch {
+	case strings.HasPrefix(start, "P") && strings.HasPrefix(end, "P"):
+		return Interval{}, ErrBadFormat
+	case strings.HasPrefix(start, "P"):
+		if i.Period, err = c.period(start); err != nil {
+			return Interval{}, err
+		}
+		if i.End, err = c.time(end); err != nil {
+			return Interval{}, err
+		}
+		if i.Start, err = i.Period.neg().AddTo(i.End); err != nil {
+			return Interval{}, err
+		}
+	case strings.HasPrefix(end, "P"):
+		if i.Start, err = c.time(start); err != nil {
+			return Interval{}, err
+		}
+		if i.Period, err = c.period(end); err != nil {
+			return Interval{}, err
+		}
+		if i.End, err = i.Period.AddTo(i.Start); err != nil {
+			return Interval{}, err
+		}
+	default:
+		if start != openEnd {
+			if i.Start, err = c.time(start); err != nil {
+				return Interval{}, err
+			}
+		}
+		if end == openEnd {
+			break
+		}
+		if i.End, err = c.time(end); err != nil && start != openEnd {
+			i.End, err = parseConciseEnd(start, i.Start, end)
+		}
+		if err != nil {
+			return Interval{}, err
+		}
+	}
+
+	if i.Start.IsZero() && start != openEnd || i.End.IsZero() && end != openEnd {
+		return Interval{}, ErrRange
+	}
+	if !i.Start.IsZero() && !i.End.IsZero() && i.End.Before(i.Start) {
+		return Interval{}, ErrRange
+	}
+	if c.maxSpan > 0 && i.Duration() > c.maxSpan {
+		return Interval{}, ErrRange
+	}
+	return i, nil
+}
+
+// time parses a time in an interval.
+func (c *intervalConfig) time(s string) (time.Time, error) {
+	if c.offsets && !hasOffset(s) {
+		return time.Time{}, ErrBadFormat
+	}
+	return parseTime(s)
+}
+
+// period parses the duration of an interval.
+func (c *intervalConfig) period(s string) (Components, error) {
+	p, err := ParseComponents(s)
+	switch {
+	case err != nil:
+		return Components{}, err
+	case c.exact && p.Years != 0:
+		return Components{}, ErrNoYear
+	case c.exact && p.Months != 0:
+		return Components{}, ErrNoMonth
+	}
+	return p, nil
+}
+
+// hasOffset reports whether a time in an interval ends with a UTC offset or
+// "Z".
+func hasOffset(s string) bool {
+	t := strings.IndexByte(s, 'T')
+	return strings.HasSuffix(s, "Z") || t != -1 && strings.LastIndexAny(s, "+-") > t
+}
+
+// openEnd is the open end of an interval.
+const openEnd = ".."
+
+// Duration returns the exact length of i. As for time.Time.Sub, lengths that
+// do not fit in a time.Duration are clamped, as are those of intervals with an
+// open end.
+func (i Interval) Duration() time.Duration {
+	if i.Start.IsZero() || i.End.IsZero() {
+		return math.MaxInt64
+	}
+	return i.End.Sub(i.Start)
+}
+
+// IntervalForm selects how Interval.Format writes an interval.
+type IntervalForm int
+
+const (
+	// IntervalStartEnd writes the start and end, e.g.
+	// "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z".
+	IntervalStartEnd IntervalForm = iota
+
+	// IntervalStartDuration writes the start and duration, e.g.
+	// "2007-03-01T13:00:00Z/P1Y2M10DT2H30M".
+	IntervalStartDuration
+
+	// IntervalDurationEnd writes the duration and end, e.g.
+	// "P1Y2M10DT2H30M/2008-05-11T15:30:00Z".
+	IntervalDurationEnd
+
+	// IntervalConcise writes the start and end, leaving out the elements of
+	// the end that are the same as the start, e.g.
+	// "2007-12-14T13:30:00Z/15:30:00". The end is written in the UTC offset
+	// of the start, which it shares.
+	IntervalConcise
+)
+
+// timeLayout is the layout of the times written by Interval.Format.
+const timeLayout = time.RFC3339Nano
+
+// Format returns i in the given form, which ParseInterval accepts. Times are
+// written in the extended format with the fraction of a second, if any, and
+// their UTC offset, and open ends as "..". Intervals with an open end have no
+// duration, so they are written in the IntervalStartEnd form for
+// IntervalConcise and fail with ErrRange for the forms with a duration. The
+// duration is Period as written by FormatComponents with opts, or when Period
+// is zero, the length of i as written by FormatWithOptions with
+// LargestUnit(Day) followed by opts, so that the duration is exact. Errors are
+// those of the function writing the duration, or ErrBadFormat for an unknown
+// form.
+func (i Interval) Format(form IntervalForm, opts ...FormatOption) (string, error) {
+	open := i.Start.IsZero() || i.End.IsZero()
+	switch {
+	case form == IntervalStartEnd || open && form == IntervalConcise:
+		return formatEnd(i.Start) + "/" + formatEnd(i.End), nil
+	case form == IntervalConcise:
+		return i.Start.Format(timeLayout) + "/" + conciseEnd(i.Start, i.End), nil
+	case form != IntervalStartDuration && form != IntervalDurationEnd:
+		return "", ErrBadFormat
+	case open:
+		return "", ErrRange
+	}
+
+	var d string
+	var err error
+	if i.Period != (Components{}) {
+		d, err = FormatComponents(i.Period, opts...)
+	} else {
+		d, err = FormatWithOptions(i.Duration(), append([]FormatOption{LargestUnit(Day)}, opts...)...)
+	}
+	if err != nil {
+		return "", err
+	}
+	if form == IntervalStartDuration {
+		return i.Start.Format(timeLayout) + "/" + d, nil
+	}
+	return d + "/" + i.End.Format(timeLayout), nil
+}
+
+// String returns i as written by Format in the IntervalStartEnd form.
+func (i Interval) String() string {
+	s, _ := i.Format(IntervalStartEnd)
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler, and so JSON encoding as a
+// string. Intervals are written by Format in the IntervalStartDuration form
+// if they have a Period, so that it is kept, and otherwise in the
+// IntervalStartEnd form.
+func (i Interval) MarshalText() ([]byte, error) {
+	form := IntervalStartEnd
+	if i.Period != (Components{}) {
+		form = IntervalStartDuration
+	}
+	s, err := i.Format(form)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, and so JSON decoding
+// from a string, parsing an interval in any form accepted by ParseInterval.
+func (i *Interval) UnmarshalText(b []byte) error {
+	v, err := ParseInterval(string(b))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// formatEnd returns t as written by Interval.Format for an end of an
+// interval.
+func formatEnd(t time.Time) string {
+	if t.IsZero() {
+		return openEnd
+	}
+	return t.Format(timeLayout)
+}
+
+// timeFields splits a time in the extended format without a UTC offset into
+// its elements and the separators between them, e.g. "2007", "12", "14",
+// "13", "30" and "00.5" separated by "-", "-", "T", ":" and ":".
+func timeFields(s string) (fields, seps []string) {
+	for {
+		i := strings.IndexAny(s, "-T:")
+		if i == -1 {
+			return append(fields, s), seps
+		}
+		fields, seps = append(fields, s[:i]), append(seps, s[i:i+1])
+		s = s[i+1:]
+	}
+}
+
+// conciseEnd returns end in the location of start without the leading
+// elements it shares with start, and without a UTC offset.
+func conciseEnd(start, end time.Time) string {
+	const layout = "2006-01-02T15:04:05.999999999"
+	sf, _ := timeFields(start.Format(layout))
+	ef, seps := timeFields(end.In(start.Location()).Format(layout))
+
+	n := 0
+	for n < len(ef)-1 && ef[n] == sf[n] {
+		n++
+	}
+	b := []byte(ef[n])
+	for j := n + 1; j < len(ef); j++ {
+		b = append(b, seps[j-1]...)
+		b = append(b, ef[j]...)
+	}
+	return string(b)
+}
+
+// Contains reports whether t is within i. Intervals include their start but
+// not their end, so that adjacent intervals do not overlap.
+func (i Interval) Contains(t time.Time) bool {
+	return (i.Start.IsZero() || !t.Before(i.Start)) && (i.End.IsZero() || t.Before(i.End))
+}
+
+// Overlaps reports whether i and other have any time in common. Intervals
+// that only meet at an end do not overlap, and neither do empty intervals.
+func (i Interval) Overlaps(other Interval) bool {
+	return before(i.Start, other.End) && before(other.Start, i.End) &&
+		before(i.Start, i.End) && before(other.Start, other.End)
+}
+
+// Intersect returns the time that i and other have in common, and whether
+// they overlap at all. The result has no Period.
+func (i Interval) Intersect(other Interval) (Interval, bool) {
+	if !i.Overlaps(other) {
+		return Interval{}, false
+	}
+
+	r := Interval{Start: i.Start, End: i.End}
+	if r.Start.IsZero() || other.Start.After(r.Start) {
+		r.Start = other.Start
+	}
+	if r.End.IsZero() || !other.End.IsZero() && other.End.Before(r.End) {
+		r.End = other.End
+	}
+	return r, true
+}
+
+// before reports whether the start of an interval is before the end of one,
+// where a zero start is before any end and a zero end after any start.
+func before(start, end time.Time) bool {
+	return start.IsZero() || end.IsZero() || start.Before(end)
+}
+
+// parseConciseEnd parses end written in the concise form of an interval whose
+// start is written as s and parsed as start: its elements replace the last
+// elements of s, and it has the UTC offset of s.
+func parseConciseEnd(s string, start time.Time, end string) (time.Time, error) {
+	if hasOffset(s) {
+		s = s[:strings.LastIndexAny(s, "Z+-")]
+	}
+
+	sf, sseps := timeFields(s)
+	ef, eseps := timeFields(end)
+	n := len(sf) - len(ef)
+	if n <= 0 || !slices.Equal(eseps, sseps[n:]) || strings.ContainsAny(end, "Z+") {
+		return time.Time{}, ErrBadFormat
+	}
+
+	var b []byte
+	for j := range n {
+		b = append(b, sf[j]...)
+		b = append(b, sseps[j]...)
+	}
+	u, err := parseTime(string(append(b, end...)))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), start.Location()), nil
+}
+
+// parseTime parses a time in one of intervalLayouts.
+func parseTime(s string) (time.Time, error) {
+	return parseTimeIn(s, time.UTC)
+}
+
+// parseTimeIn parses a time in one of intervalLayouts, taking one without a
+// UTC offset as in loc.
+func parseTimeIn(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range intervalLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrBadFormat
+}
@@ -0,0 +1,486 @@
+package duration
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	plus2 := time.FixedZone("", 2*60*60)
+	vecs := []struct {
+		in    string
+		start time.Time
+		end   time.Time
+		err   error
+	}{
+		{
+			"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+			nil,
+		},
+		{
+			"2007-03-01T13:00:00.5+02:00/2007-03-01T13:00:01,25+02:00",
+			time.Date(2007, 3, 1, 13, 0, 0, 5e8, plus2),
+			time.Date(2007, 3, 1, 13, 0, 1, 25e7, plus2),
+			nil,
+		},
+		{
+			"2007-03-01T13:00/2007-03-01T14:30",
+			time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2007, 3, 1, 14, 30, 0, 0, time.UTC),
+			nil,
+		},
+		{
+			"2007-03-01/2007-03-08",
+			time.Date(2007, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2007, 3, 8, 0, 0, 0, 0, time.UTC),
+			nil,
+		},
+		{
+			"20070301T130000Z/20080511T153000+0200",
+			time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2008, 5, 11, 15, 30, 0, 0, plus2),
+			nil,
+		},
+		{
+			" 2007-03-01/2007-03-01\n",
+			time.Date(2007, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2007, 3, 1, 0, 0, 0, 0, time.UTC),
+			nil,
+		},
+
+		{"2008-05-11/2007-03-01", time.Time{}, time.Time{}, ErrRange},
+		{"2007-03-01T13:00:00Z", time.Time{}, time.Time{}, ErrBadFormat},
+		{"2007-03-01/2007-03-08/2007-03-09", time.Time{}, time.Time{}, ErrBadFormat},
+		{"2007-03-01/", time.Time{}, time.Time{}, ErrBadFormat},
+		{"/2007-03-01", time.Time{}, time.Time{}, ErrBadFormat},
+		{"2007-03-01 / 2007-03-08", time.Time{}, time.Time{}, ErrBadFormat},
+		{"2007-02-30/2007-03-08", time.Time{}, time.Time{}, ErrBadFormat},
+		{"yesterday/today", time.Time{}, time.Time{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.True(t, vec.start.Equal(i.Start), "%s: start %v", vec.in, i.Start)
+		assert.True(t, vec.end.Equal(i.End), "%s: end %v", vec.in, i.End)
+	}
+}
+
+func TestParseIntervalPeriod(t *testing.T) {
+	t.Parallel()
+
+	plus2 := time.FixedZone("", 2*60*60)
+	vecs := []struct {
+		in     string
+		start  time.Time
+		end    time.Time
+		period Components
+		err    error
+	}{
+		{
+			"2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+			Components{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30},
+			nil,
+		},
+		{
+			"P1Y2M10DT2H30M/2008-05-11T15:30:00Z",
+			time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC),
+			Components{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30},
+			nil,
+		},
+		{
+			"2007-01-31/P1M",
+			time.Date(2007, 1, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2007, 2, 28, 0, 0, 0, 0, time.UTC),
+			Components{Months: 1},
+			nil,
+		},
+		{
+			"P1M/2007-03-31",
+			time.Date(2007, 2, 28, 0, 0, 0, 0, time.UTC),
+			time.Date(2007, 3, 31, 0, 0, 0, 0, time.UTC),
+			Components{Months: 1},
+			nil,
+		},
+		{
+			"2007-03-01T23:00:00+02:00/PT2H",
+			time.Date(2007, 3, 1, 23, 0, 0, 0, plus2),
+			time.Date(2007, 3, 2, 1, 0, 0, 0, plus2),
+			Components{Hours: 2},
+			nil,
+		},
+		{
+			"2007-03-01/P1W",
+			time.Date(2007, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2007, 3, 8, 0, 0, 0, 0, time.UTC),
+			Components{Weeks: 1},
+			nil,
+		},
+
+		{"P1D/P1D", time.Time{}, time.Time{}, Components{}, ErrBadFormat},
+		{"2007-03-01/P", time.Time{}, time.Time{}, Components{}, ErrBadFormat},
+		{"2007-03-01/P0.5M", time.Time{}, time.Time{}, Components{}, ErrBadFormat},
+		{"2007-03-01/-P1D", time.Time{}, time.Time{}, Components{}, ErrBadFormat},
+		{"P1D/tomorrow", time.Time{}, time.Time{}, Components{}, ErrBadFormat},
+		{"2007-03-01/P999999999999Y", time.Time{}, time.Time{}, Components{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.True(t, vec.start.Equal(i.Start), "%s: start %v", vec.in, i.Start)
+		assert.True(t, vec.end.Equal(i.End), "%s: end %v", vec.in, i.End)
+		assert.Equal(t, vec.period, i.Period, vec.in)
+	}
+}
+
+func TestParseIntervalOptions(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []IntervalOption
+		err  error
+	}{
+		{"2007-03-01T13:00:00Z/2007-03-01T16:30:00+02:00", []IntervalOption{RequireOffset()}, nil},
+		{"2007-03-01T13:00:00-05:00/PT1H", []IntervalOption{RequireOffset()}, nil},
+		{"2007-03-01T13:00Z/15T14:30", []IntervalOption{RequireOffset()}, nil},
+		{"2007-03-01T13:00:00Z/..", []IntervalOption{RequireOffset()}, nil},
+		{"2007-03-01T13:00/2007-03-01T14:30Z", []IntervalOption{RequireOffset()}, ErrBadFormat},
+		{"PT1H/2007-03-01T14:30", []IntervalOption{RequireOffset()}, ErrBadFormat},
+		{"2007-03-01/2007-03-08", []IntervalOption{RequireOffset()}, ErrBadFormat},
+
+		{"2007-03-01T13:00:00Z/P9DT3H", []IntervalOption{ExactPeriod()}, nil},
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", []IntervalOption{ExactPeriod()}, nil},
+		{"2007-03-01T13:00:00Z/P1M", []IntervalOption{ExactPeriod()}, ErrNoMonth},
+		{"P1Y/2008-05-11T15:30:00Z", []IntervalOption{ExactPeriod()}, ErrNoYear},
+
+		{"2007-03-01T13:00:00Z/PT1H", []IntervalOption{MaxSpan(time.Hour)}, nil},
+		{"2007-03-01T13:00:00Z/PT1H0.001S", []IntervalOption{MaxSpan(time.Hour)}, ErrRange},
+		{"2007-03-01/2007-03-08", []IntervalOption{MaxSpan(24 * time.Hour)}, ErrRange},
+		{"P1M/2007-03-01T00:00:00Z", []IntervalOption{MaxSpan(30 * 24 * time.Hour)}, nil},
+		{"P1M/2007-04-01T00:00:00Z", []IntervalOption{MaxSpan(30 * 24 * time.Hour)}, ErrRange},
+		{"../2007-03-01T13:00:00Z", []IntervalOption{MaxSpan(time.Hour)}, ErrRange},
+
+		{"2007-03-01T13:00:00Z/P1D", []IntervalOption{RequireOffset(), ExactPeriod(), MaxSpan(24 * time.Hour)}, nil},
+	}
+	for _, vec := range vecs {
+		_, err := ParseInterval(vec.in, vec.opts...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestIntervalDuration(t *testing.T) {
+	t.Parallel()
+
+	i, err := ParseInterval("2007-03-01T13:00:00Z/2007-03-02T14:30:00+01:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour+30*time.Minute, i.Duration())
+
+	assert.Equal(t, time.Duration(0), Interval{Start: i.Start, End: i.Start}.Duration())
+}
+
+func TestIntervalContains(t *testing.T) {
+	t.Parallel()
+
+	i, err := ParseInterval("2007-03-01T13:00:00Z/PT1H")
+	assert.NoError(t, err)
+
+	vecs := []struct {
+		in  time.Time
+		out bool
+	}{
+		{time.Date(2007, 3, 1, 12, 59, 59, 0, time.UTC), false},
+		{time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), true},
+		{time.Date(2007, 3, 1, 14, 30, 0, 0, time.FixedZone("", 3600)), true},
+		{time.Date(2007, 3, 1, 14, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, i.Contains(vec.in), vec.in)
+	}
+
+	empty := Interval{Start: i.Start, End: i.Start}
+	assert.False(t, empty.Contains(i.Start))
+}
+
+func TestIntervalIntersect(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		a, b string
+		out  string
+	}{
+		{"2007-03-01/2007-03-10", "2007-03-05/2007-03-15", "2007-03-05/2007-03-10"},
+		{"2007-03-05/2007-03-15", "2007-03-01/2007-03-10", "2007-03-05/2007-03-10"},
+		{"2007-03-01/2007-03-10", "2007-03-02/2007-03-03", "2007-03-02/2007-03-03"},
+		{"2007-03-01/2007-03-10", "2007-03-01/2007-03-10", "2007-03-01/2007-03-10"},
+		{"2007-03-01/P1D", "2007-03-01T12:00/P1D", "2007-03-01T12:00/2007-03-02"},
+		{"2007-03-01/2007-03-10", "2007-03-10/2007-03-15", ""},
+		{"2007-03-01/2007-03-10", "2007-03-11/2007-03-15", ""},
+		{"2007-03-01/2007-03-10", "2007-03-05/2007-03-05", ""},
+		{"2007-03-05/2007-03-05", "2007-03-05/2007-03-05", ""},
+	}
+
+	for _, vec := range vecs {
+		a, err := ParseInterval(vec.a)
+		assert.NoError(t, err, vec.a)
+		b, err := ParseInterval(vec.b)
+		assert.NoError(t, err, vec.b)
+
+		r, ok := a.Intersect(b)
+		assert.Equal(t, vec.out != "", ok, "%s %s", vec.a, vec.b)
+		assert.Equal(t, ok, a.Overlaps(b), "%s %s", vec.a, vec.b)
+		if vec.out == "" {
+			assert.Equal(t, Interval{}, r, "%s %s", vec.a, vec.b)
+			continue
+		}
+		out, err := ParseInterval(vec.out)
+		assert.NoError(t, err, vec.out)
+		assert.Equal(t, out, r, "%s %s", vec.a, vec.b)
+	}
+}
+
+func TestParseIntervalConcise(t *testing.T) {
+	t.Parallel()
+
+	minus5 := time.FixedZone("", -5*60*60)
+	vecs := []struct {
+		in  string
+		end time.Time
+		err error
+	}{
+		{"2007-12-14T13:30Z/15:30", time.Date(2007, 12, 14, 15, 30, 0, 0, time.UTC), nil},
+		{"2007-11-13T09:00/15T17:00", time.Date(2007, 11, 15, 17, 0, 0, 0, time.UTC), nil},
+		{"2008-02-15/03-14", time.Date(2008, 3, 14, 0, 0, 0, 0, time.UTC), nil},
+		{"2007-12-14T13:30:00-05:00/15:30:00.5", time.Date(2007, 12, 14, 15, 30, 0, 5e8, minus5), nil},
+		{"2007-12-14T13:30:00-05:00/12-31T00:00:00", time.Date(2007, 12, 31, 0, 0, 0, 0, minus5), nil},
+		{"20071214T133000Z/143000", time.Date(2007, 12, 14, 14, 30, 0, 0, time.UTC), nil},
+
+		{"2007-12-14T13:30Z/12:30", time.Time{}, ErrRange},
+		{"2007-12-14T13:30Z/15:30Z", time.Time{}, ErrBadFormat},
+		{"2007-12-14T13:30Z/15:30+01:00", time.Time{}, ErrBadFormat},
+		{"2007-12-14T13:30Z/2007-12-14T15", time.Time{}, ErrBadFormat},
+		{"2007-12-14/15:30", time.Time{}, ErrBadFormat},
+		{"2007-12-14T13:30Z/15-15:30", time.Time{}, ErrBadFormat},
+		{"2007-12-14T13:30Z/61", time.Time{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.True(t, vec.end.Equal(i.End), "%s: end %v", vec.in, i.End)
+		if err == nil {
+			assert.Equal(t, i.Start.Location(), i.End.Location(), vec.in)
+		}
+	}
+}
+
+func TestIntervalFormat(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		form IntervalForm
+		opts []FormatOption
+		out  string
+		err  error
+	}{
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", IntervalStartEnd, nil, "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", nil},
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", IntervalStartDuration, nil, "2007-03-01T13:00:00Z/P437DT2H30M", nil},
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", IntervalDurationEnd, []FormatOption{LargestUnit(Hour)}, "PT10490H30M/2008-05-11T15:30:00Z", nil},
+		{"2007-03-01T13:00:00Z/P1Y2M10DT2H30M", IntervalStartEnd, nil, "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", nil},
+		{"2007-03-01T13:00:00Z/P1Y2M10DT2H30M", IntervalStartDuration, nil, "2007-03-01T13:00:00Z/P1Y2M10DT2H30M", nil},
+		{"P1Y2M10DT2H30M/2008-05-11T15:30:00Z", IntervalDurationEnd, nil, "P1Y2M10DT2H30M/2008-05-11T15:30:00Z", nil},
+		{"2007-03-01T13:00:00.25+01:00/PT0.5S", IntervalStartEnd, nil, "2007-03-01T13:00:00.25+01:00/2007-03-01T13:00:00.75+01:00", nil},
+		{"2007-12-14T13:30Z/15:30", IntervalConcise, nil, "2007-12-14T13:30:00Z/15:30:00", nil},
+		{"2007-11-13T09:00Z/2007-11-15T17:00Z", IntervalConcise, nil, "2007-11-13T09:00:00Z/15T17:00:00", nil},
+		{"2008-02-15/2008-03-14", IntervalConcise, nil, "2008-02-15T00:00:00Z/03-14T00:00:00", nil},
+		{"2008-02-15/2009-02-15", IntervalConcise, nil, "2008-02-15T00:00:00Z/2009-02-15T00:00:00", nil},
+		{"2008-02-15T10:00:00Z/2008-02-15T11:00:00+01:00", IntervalConcise, nil, "2008-02-15T10:00:00Z/00", nil},
+		{"2008-02-15T10:00:00Z/PT1.5S", IntervalConcise, nil, "2008-02-15T10:00:00Z/01.5", nil},
+		{"2008-02-15/2008-02-16", 9, nil, "", ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+
+		s, err := i.Format(vec.form, vec.opts...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+		if err != nil {
+			continue
+		}
+
+		// Format writes what ParseInterval reads
+		j, err := ParseInterval(s)
+		assert.NoError(t, err, s)
+		assert.True(t, i.Start.Equal(j.Start) && i.End.Equal(j.End), "%s: %v", s, j)
+	}
+
+	now := time.Now()
+	_, err := Interval{Start: now, End: now, Period: Components{Months: -1}}.Format(IntervalStartDuration)
+	assert.ErrorIs(t, err, ErrNoNegative)
+}
+
+func TestIntervalString(t *testing.T) {
+	t.Parallel()
+
+	i, err := ParseInterval("2007-03-01/P1D")
+	assert.NoError(t, err)
+	assert.Equal(t, "2007-03-01T00:00:00Z/2007-03-02T00:00:00Z", i.String())
+	assert.Equal(t, "2007-03-01T00:00:00Z/2007-03-02T00:00:00Z", fmt.Sprint(i))
+}
+
+func TestParseIntervalOpen(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	vecs := []struct {
+		in  string
+		out Interval
+		err error
+	}{
+		{"2020-01-01/..", Interval{Start: date}, nil},
+		{"../2020-01-01", Interval{End: date}, nil},
+		{"../..", Interval{}, nil},
+		{"../P1D", Interval{}, ErrBadFormat},
+		{"P1D/..", Interval{}, ErrBadFormat},
+		{"../15:30", Interval{}, ErrBadFormat},
+		{"2020-01-01/...", Interval{}, ErrBadFormat},
+		{"2020-01-01/", Interval{}, ErrBadFormat},
+
+		// the zero time.Time cannot be told from an open end
+		{"0001-01-01T00:00:00Z/2020-01-01", Interval{}, ErrRange},
+		{"0001-01-01/..", Interval{}, ErrRange},
+		{"../0001-01-01T02:00:00+02:00", Interval{}, ErrRange},
+		{"P1D/0001-01-02", Interval{}, ErrRange},
+		{"0001-01-01T00:00:00.000000001Z/2020-01-01", Interval{Start: time.Date(1, 1, 1, 0, 0, 0, 1, time.UTC), End: date}, nil},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, i, vec.in)
+	}
+
+	_, err := ParseRepeatingInterval("R/2020-01-01/..")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestIntervalOpen(t *testing.T) {
+	t.Parallel()
+
+	date := func(y int) time.Time {
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	from := Interval{Start: date(2020)}
+	until := Interval{End: date(2020)}
+	always := Interval{}
+
+	assert.Equal(t, time.Duration(math.MaxInt64), from.Duration())
+	assert.Equal(t, time.Duration(math.MaxInt64), until.Duration())
+
+	assert.True(t, from.Contains(date(3000)))
+	assert.False(t, from.Contains(date(2019)))
+	assert.True(t, until.Contains(date(1)))
+	assert.False(t, until.Contains(date(2020)))
+	assert.True(t, always.Contains(date(2020)))
+
+	assert.False(t, from.Overlaps(until))
+	r, ok := Interval{Start: date(2019)}.Intersect(Interval{End: date(2021)})
+	assert.True(t, ok)
+	assert.Equal(t, Interval{Start: date(2019), End: date(2021)}, r)
+	r, ok = always.Intersect(Interval{Start: date(2019), End: date(2021)})
+	assert.True(t, ok)
+	assert.Equal(t, Interval{Start: date(2019), End: date(2021)}, r)
+	r, ok = always.Intersect(from)
+	assert.True(t, ok)
+	assert.Equal(t, from, r)
+
+	vecs := []struct {
+		in   Interval
+		form IntervalForm
+		out  string
+		err  error
+	}{
+		{from, IntervalStartEnd, "2020-01-01T00:00:00Z/..", nil},
+		{until, IntervalStartEnd, "../2020-01-01T00:00:00Z", nil},
+		{always, IntervalConcise, "../..", nil},
+		{from, IntervalStartDuration, "", ErrRange},
+		{until, IntervalDurationEnd, "", ErrRange},
+	}
+
+	for _, vec := range vecs {
+		s, err := vec.in.Format(vec.form)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
+
+func TestIntervalText(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out string
+	}{
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z"},
+		{"2007-03-01T13:00:00Z/P1Y2M10DT2H30M", "2007-03-01T13:00:00Z/P1Y2M10DT2H30M"},
+		{"P1Y2M10DT2H30M/2008-05-11T15:30:00Z", "2007-03-01T13:00:00Z/P1Y2M10DT2H30M"},
+		{"2007-12-14T13:30Z/15:30", "2007-12-14T13:30:00Z/2007-12-14T15:30:00Z"},
+		{"2020-01-01/..", "2020-01-01T00:00:00Z/.."},
+	}
+
+	for _, vec := range vecs {
+		var i Interval
+		assert.NoError(t, i.UnmarshalText([]byte(vec.in)), vec.in)
+		b, err := i.MarshalText()
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, string(b), vec.in)
+	}
+
+	i := Interval{End: time.Now()}
+	assert.ErrorIs(t, i.UnmarshalText([]byte("2020-01-01")), ErrBadFormat)
+	assert.False(t, i.End.IsZero())
+
+	_, err := Interval{Period: Components{Minutes: 10}}.MarshalText()
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestIntervalJSON(t *testing.T) {
+	t.Parallel()
+
+	type booking struct {
+		Slot Interval  `json:"slot"`
+		Alt  *Interval `json:"alt"`
+	}
+
+	var b booking
+	err := json.Unmarshal([]byte(`{"slot":"2020-01-01T09:00Z/PT1H","alt":null}`), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), b.Slot.End)
+	assert.Nil(t, b.Alt)
+
+	out, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"slot":"2020-01-01T09:00:00Z/PT1H","alt":null}`, string(out))
+
+	err = json.Unmarshal([]byte(`{"slot":"2020-01-02/2020-01-01"}`), &b)
+	assert.ErrorIs(t, err, ErrRange)
+	err = json.Unmarshal([]byte(`{"slot":3600}`), &b)
+	assert.Error(t, err)
+}
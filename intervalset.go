@@ -0,0 +1,83 @@
+package duration
+
+import "slices"
+
+// Union returns the time covered by any of the intervals in sets as a sorted
+// set of intervals that neither overlap nor meet, merging intervals that do
+// and dropping empty ones. The results have no Period. Union of a single set
+// normalizes it for Difference and Gaps.
+func Union(sets ...[]Interval) []Interval {
+	var all []Interval
+	for _, set := range sets {
+		for _, i := range set {
+			if before(i.Start, i.End) {
+				all = append(all, Interval{Start: i.Start, End: i.End})
+			}
+		}
+	}
+	slices.SortFunc(all, func(a, b Interval) int {
+		if a.Start.IsZero() || b.Start.IsZero() {
+			// open starts first
+			return boolCmp(!a.Start.IsZero(), !b.Start.IsZero())
+		}
+		return a.Start.Compare(b.Start)
+	})
+
+	var out []Interval
+	for _, i := range all {
+		n := len(out)
+		if n == 0 || !out[n-1].End.IsZero() && out[n-1].End.Before(i.Start) {
+			out = append(out, i)
+			continue
+		}
+		if last := &out[n-1]; !last.End.IsZero() && (i.End.IsZero() || i.End.After(last.End)) {
+			last.End = i.End
+		}
+	}
+	return out
+}
+
+// Difference returns the time covered by a but not by b, in the form returned
+// by Union.
+func Difference(a, b []Interval) []Interval {
+	b = Union(b)
+
+	var out []Interval
+	for _, x := range Union(a) {
+		covered := false
+		for _, y := range b {
+			if !x.Overlaps(y) {
+				continue
+			}
+			if !y.Start.IsZero() && (x.Start.IsZero() || x.Start.Before(y.Start)) {
+				out = append(out, Interval{Start: x.Start, End: y.Start})
+			}
+			if y.End.IsZero() || !x.End.IsZero() && !y.End.Before(x.End) {
+				covered = true
+				break
+			}
+			x.Start = y.End
+		}
+		if !covered {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Gaps returns the time within that none of the intervals in s cover, in the
+// form returned by Union, for finding the free time in a schedule.
+func Gaps(s []Interval, within Interval) []Interval {
+	return Difference([]Interval{within}, s)
+}
+
+// boolCmp orders false before true.
+func boolCmp(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
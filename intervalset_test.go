@@ -0,0 +1,133 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// intervals parses each of ss with ParseInterval.
+func intervals(t *testing.T, ss ...string) []Interval {
+	t.Helper()
+
+	var out []Interval
+	for _, s := range ss {
+		i, err := ParseInterval(s)
+		require.NoError(t, err, s)
+		out = append(out, Interval{Start: i.Start, End: i.End})
+	}
+	return out
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  [][]string
+		out []string
+	}{
+		{nil, nil},
+		{
+			[][]string{{"2020-01-05/2020-01-07", "2020-01-01/2020-01-03"}},
+			[]string{"2020-01-01/2020-01-03", "2020-01-05/2020-01-07"},
+		},
+		{
+			[][]string{{"2020-01-01/2020-01-03", "2020-01-03/2020-01-04"}, {"2020-01-02/2020-01-02T12:00"}},
+			[]string{"2020-01-01/2020-01-04"},
+		},
+		{
+			[][]string{{"2020-01-01/2020-01-10", "2020-01-02/2020-01-03"}, {"2020-01-09/2020-01-11"}},
+			[]string{"2020-01-01/2020-01-11"},
+		},
+		{
+			[][]string{{"2020-01-01/2020-01-01", "2020-01-05/P1D"}},
+			[]string{"2020-01-05/2020-01-06"},
+		},
+		{
+			[][]string{{"2020-01-05/..", "2020-01-01/2020-01-02"}, {"../2019-01-01", "2020-01-06/2020-01-07"}},
+			[]string{"../2019-01-01", "2020-01-01/2020-01-02", "2020-01-05/.."},
+		},
+		{
+			[][]string{{"2020-01-05/..", "../2020-01-06"}},
+			[]string{"../.."},
+		},
+	}
+
+	for _, vec := range vecs {
+		var sets [][]Interval
+		for _, set := range vec.in {
+			sets = append(sets, intervals(t, set...))
+		}
+		assert.Equal(t, intervals(t, vec.out...), Union(sets...), vec.in)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		a, b []string
+		out  []string
+	}{
+		{
+			[]string{"2020-01-01/2020-01-10"},
+			[]string{"2020-01-03/2020-01-04", "2020-01-06/2020-01-07"},
+			[]string{"2020-01-01/2020-01-03", "2020-01-04/2020-01-06", "2020-01-07/2020-01-10"},
+		},
+		{
+			[]string{"2020-01-01/2020-01-10"},
+			[]string{"2019-12-01/2020-01-02", "2020-01-09/2020-02-01"},
+			[]string{"2020-01-02/2020-01-09"},
+		},
+		{
+			[]string{"2020-01-01/2020-01-10", "2020-01-12/2020-01-14"},
+			[]string{"2020-01-05/2020-01-13"},
+			[]string{"2020-01-01/2020-01-05", "2020-01-13/2020-01-14"},
+		},
+		{
+			[]string{"2020-01-01/2020-01-10"},
+			[]string{"2019-01-01/2021-01-01"},
+			nil,
+		},
+		{
+			[]string{"2020-01-01/2020-01-10"},
+			[]string{"2020-02-01/2020-02-10"},
+			[]string{"2020-01-01/2020-01-10"},
+		},
+		{
+			[]string{"../.."},
+			[]string{"2020-01-01/2020-01-10"},
+			[]string{"../2020-01-01", "2020-01-10/.."},
+		},
+		{
+			[]string{"2020-01-01/.."},
+			[]string{"2020-01-05/..", "../2020-01-02"},
+			[]string{"2020-01-02/2020-01-05"},
+		},
+		{nil, []string{"2020-01-01/2020-01-10"}, nil},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, intervals(t, vec.out...), Difference(intervals(t, vec.a...), intervals(t, vec.b...)), "%v - %v", vec.a, vec.b)
+	}
+}
+
+func TestGaps(t *testing.T) {
+	t.Parallel()
+
+	booked := intervals(t,
+		"2020-01-01T09:00Z/PT1H",
+		"2020-01-01T13:00Z/PT2H",
+		"2020-01-01T10:00Z/PT30M",
+		"2020-01-01T16:00Z/PT3H",
+	)
+	day := intervals(t, "2020-01-01T08:00Z/2020-01-01T18:00Z")[0]
+
+	assert.Equal(t, intervals(t,
+		"2020-01-01T08:00Z/2020-01-01T09:00Z",
+		"2020-01-01T10:30Z/2020-01-01T13:00Z",
+		"2020-01-01T15:00Z/2020-01-01T16:00Z",
+	), Gaps(booked, day))
+	assert.Nil(t, Gaps(booked, intervals(t, "2020-01-01T09:00Z/2020-01-01T10:30Z")[0]))
+}
@@ -0,0 +1,56 @@
+// Package isocobra adds ISO8601 duration flags, with shell completion, to
+// github.com/spf13/cobra commands.
+package isocobra
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/spf13/cobra"
+)
+
+// Suggestions are the durations offered by Complete for an empty or partial
+// flag value.
+var Suggestions = []string{"PT30S", "PT1M", "PT5M", "PT15M", "PT30M", "PT1H", "PT12H", "P1D", "P7D"}
+
+// DurationVar defines a duration flag with the given name, default value and
+// usage on the flags of cmd, stores its value in p, and registers Complete
+// for it.
+func DurationVar(cmd *cobra.Command, p *duration.Duration, name string, value time.Duration, usage string) {
+	*p = duration.Duration(value)
+	cmd.Flags().Var(p, name, usage)
+	_ = cmd.RegisterFlagCompletionFunc(name, Complete)
+}
+
+// Complete is a cobra completion function for duration flags. It offers the
+// Suggestions that start with the text typed so far and, once a number has
+// been typed (e.g. "PT5"), the units that may follow it.
+func Complete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := strings.ToUpper(toComplete)
+
+	var out []string
+	if n := len(prefix); n > 0 && prefix[n-1] >= '0' && prefix[n-1] <= '9' {
+		designators := "YWD"
+		if strings.Contains(prefix, "T") {
+			designators = "HMS"
+		}
+		for _, c := range designators {
+			if s := prefix + string(c); valid(s) {
+				out = append(out, s)
+			}
+		}
+	}
+	for _, s := range Suggestions {
+		if strings.HasPrefix(s, prefix) && !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+func valid(s string) bool {
+	_, err := duration.ParseWithOptions(s, duration.AllowNegative())
+	return err == nil
+}
@@ -0,0 +1,52 @@
+package isocobra
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationVar(t *testing.T) {
+	t.Parallel()
+
+	var timeout duration.Duration
+	cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	DurationVar(cmd, &timeout, "timeout", 30*time.Second, "request timeout")
+
+	assert.Equal(t, duration.Duration(30*time.Second), timeout)
+	f := cmd.Flags().Lookup("timeout")
+	assert.Equal(t, "duration", f.Value.Type())
+	assert.Equal(t, "PT30S", f.DefValue)
+
+	cmd.SetArgs([]string{"--timeout", "PT1M30S"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, duration.Duration(90*time.Second), timeout)
+
+	_, ok := cmd.GetFlagCompletionFunc("timeout")
+	assert.True(t, ok)
+}
+
+func TestComplete(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out []string
+	}{
+		{"", Suggestions},
+		{"PT1", []string{"PT1H", "PT1M", "PT1S", "PT15M", "PT12H"}},
+		{"pt5", []string{"PT5H", "PT5M", "PT5S"}},
+		{"P1", []string{"P1Y", "P1W", "P1D"}},
+		{"P1DT", nil},
+		{"x", nil},
+	}
+
+	for _, vec := range vecs {
+		out, directive := Complete(nil, nil, vec.in)
+		assert.Equal(t, vec.out, out, vec.in)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+}
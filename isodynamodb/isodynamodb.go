@@ -0,0 +1,45 @@
+// Package isodynamodb stores ISO8601 durations in Amazon DynamoDB through the
+// attributevalue package of github.com/aws/aws-sdk-go-v2.
+package isodynamodb
+
+import (
+	"strconv"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Duration is a duration.Duration that attributevalue.Marshal stores as an
+// ISO8601 string attribute (e.g. "PT1H30M"). It converts freely to and from
+// duration.Duration and time.Duration.
+type Duration duration.Duration
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler, encoding
+// d as a string attribute.
+func (d Duration) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	b, err := duration.Duration(d).MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return &types.AttributeValueMemberS{Value: string(b)}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler. It
+// accepts a string attribute in ISO8601 form with a leading "-" permitted, or
+// a number attribute holding seconds (e.g. "5400"), as read by
+// duration.Duration.UnmarshalJSON. A NULL attribute leaves d unchanged; any
+// other attribute fails with duration.ErrBadFormat.
+func (d *Duration) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberS:
+		return (*duration.Duration)(d).UnmarshalText([]byte(av.Value))
+	case *types.AttributeValueMemberN:
+		if _, err := strconv.ParseFloat(av.Value, 64); err != nil {
+			return duration.ErrBadFormat
+		}
+		return (*duration.Duration)(d).UnmarshalJSON([]byte(av.Value))
+	case *types.AttributeValueMemberNULL:
+		return nil
+	}
+	return duration.ErrBadFormat
+}
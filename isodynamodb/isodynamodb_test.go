@@ -0,0 +1,58 @@
+package isodynamodb
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalDynamoDBAttributeValue(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Duration
+		out string
+	}{
+		{Duration(90 * time.Minute), "PT1H30M"},
+		{Duration(-time.Second), "-PT1S"},
+		{0, "P0Y"},
+	}
+
+	for _, vec := range vecs {
+		av, err := vec.in.MarshalDynamoDBAttributeValue()
+		require.NoError(t, err)
+		assert.Equal(t, &types.AttributeValueMemberS{Value: vec.out}, av)
+	}
+}
+
+func TestUnmarshalDynamoDBAttributeValue(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  types.AttributeValue
+		out Duration
+		err error
+	}{
+		{&types.AttributeValueMemberS{Value: "PT1H30M"}, Duration(90 * time.Minute), nil},
+		{&types.AttributeValueMemberS{Value: "-PT1S"}, Duration(-time.Second), nil},
+		{&types.AttributeValueMemberN{Value: "5400"}, Duration(90 * time.Minute), nil},
+		{&types.AttributeValueMemberN{Value: "0.25"}, Duration(250 * time.Millisecond), nil},
+		{&types.AttributeValueMemberNULL{Value: true}, Duration(time.Hour), nil},
+		{&types.AttributeValueMemberS{Value: "1h"}, Duration(time.Hour), duration.ErrBadFormat},
+		{&types.AttributeValueMemberS{Value: "P1M"}, Duration(time.Hour), duration.ErrNoMonth},
+		{&types.AttributeValueMemberN{Value: "x"}, Duration(time.Hour), duration.ErrBadFormat},
+		{&types.AttributeValueMemberN{Value: "1e30"}, Duration(time.Hour), duration.ErrRange},
+		{&types.AttributeValueMemberBOOL{Value: true}, Duration(time.Hour), duration.ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		d := Duration(time.Hour)
+		err := d.UnmarshalDynamoDBAttributeValue(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%#v", vec.in)
+		assert.Equal(t, vec.out, d, "%#v", vec.in)
+	}
+}
@@ -0,0 +1,59 @@
+// Package isok8s converts between ISO8601 durations and the duration forms used
+// by Kubernetes: metav1.Duration, and the strings in the syntax of
+// time.ParseDuration (e.g. "1h30m") that it encodes to and the API server
+// accepts.
+package isok8s
+
+import (
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Strict returns the parse options for ISO8601 durations that map exactly
+// onto values the API server accepts: year elements, whose nominal length
+// Kubernetes has no notion of, are rejected with duration.ErrNoYear, as are
+// months; negative durations are accepted as they are by the API server; and
+// no surrounding white space is allowed.
+func Strict() []duration.ParseOption {
+	return []duration.ParseOption{
+		duration.RejectYears(),
+		duration.AllowNegative(),
+		duration.Trim(duration.TrimNone),
+	}
+}
+
+// ToMeta parses an ISO8601 duration, as accepted by duration.ParseWithOptions
+// with opts, into a metav1.Duration.
+func ToMeta(s string, opts ...duration.ParseOption) (metav1.Duration, error) {
+	d, err := duration.ParseWithOptions(s, opts...)
+	if err != nil {
+		return metav1.Duration{}, err
+	}
+	return metav1.Duration{Duration: d}, nil
+}
+
+// FromMeta returns d in ISO8601 form, as written by
+// duration.FormatWithOptions with opts.
+func FromMeta(d metav1.Duration, opts ...duration.FormatOption) (string, error) {
+	return duration.FormatWithOptions(d.Duration, opts...)
+}
+
+// ToString converts an ISO8601 duration, as accepted by
+// duration.ParseWithOptions with opts, to the string form of metav1.Duration
+// (e.g. "1h30m0s" for "PT1H30M").
+func ToString(s string, opts ...duration.ParseOption) (string, error) {
+	m, err := ToMeta(s, opts...)
+	if err != nil {
+		return "", err
+	}
+	return m.Duration.String(), nil
+}
+
+// FromString converts a duration in the string form accepted by the API
+// server for metav1.Duration fields (e.g. "90m") to ISO8601, as written by
+// duration.FormatWithOptions with opts. The API server parses these strings
+// with time.ParseDuration, so this is duration.FromGoString; strings it would
+// reject fail with duration.ErrBadFormat.
+func FromString(s string, opts ...duration.FormatOption) (string, error) {
+	return duration.FromGoString(s, opts...)
+}
@@ -0,0 +1,84 @@
+package isok8s
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToMeta(t *testing.T) {
+	t.Parallel()
+
+	m, err := ToMeta("PT1H30M")
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.Duration{Duration: 90 * time.Minute}, m)
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `"1h30m0s"`, string(b))
+
+	_, err = ToMeta("P1M")
+	assert.ErrorIs(t, err, duration.ErrNoMonth)
+}
+
+func TestFromMeta(t *testing.T) {
+	t.Parallel()
+
+	s, err := FromMeta(metav1.Duration{Duration: 36 * time.Hour})
+	assert.NoError(t, err)
+	assert.Equal(t, "P1DT12H", s)
+
+	s, err = FromMeta(metav1.Duration{Duration: 36 * time.Hour}, duration.LargestUnit(duration.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "PT36H", s)
+}
+
+func TestStrings(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		iso string
+		k8s string
+	}{
+		{"PT1H30M", "1h30m0s"},
+		{"P1D", "24h0m0s"},
+		{"PT0.500S", "500ms"},
+		{"-PT5S", "-5s"},
+	}
+
+	for _, vec := range vecs {
+		s, err := ToString(vec.iso, Strict()...)
+		assert.NoError(t, err, vec.iso)
+		assert.Equal(t, vec.k8s, s)
+
+		s, err = FromString(vec.k8s, duration.AllowNegative())
+		assert.NoError(t, err, vec.k8s)
+		assert.Equal(t, vec.iso, s)
+	}
+
+	_, err := FromString("1d")
+	assert.ErrorIs(t, err, duration.ErrBadFormat)
+}
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"P1Y", duration.ErrNoYear},
+		{"P1M", duration.ErrNoMonth},
+		{" PT1H", duration.ErrBadFormat},
+		{"PT1H\n", duration.ErrBadFormat},
+	}
+
+	for _, vec := range bad {
+		_, err := ToMeta(vec.in, Strict()...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
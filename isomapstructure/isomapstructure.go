@@ -0,0 +1,49 @@
+// Package isomapstructure decodes ISO8601 durations with
+// github.com/go-viper/mapstructure/v2, as used by viper, so that configuration
+// structs can declare duration fields directly.
+package isomapstructure
+
+import (
+	"reflect"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+var (
+	timeDurationType = reflect.TypeOf(time.Duration(0))
+	durationType     = reflect.TypeOf(duration.Duration(0))
+	componentsType   = reflect.TypeOf(duration.Components{})
+)
+
+// DecodeHook returns a hook that decodes strings into time.Duration,
+// duration.Duration and duration.Components fields, parsing them with
+// duration.ParseWithOptions or duration.ParseComponents and opts. Other
+// values pass through unchanged. With viper, install it using
+// viper.DecodeHook, composed with any other hooks required:
+//
+//	v.Unmarshal(&cfg, viper.DecodeHook(isomapstructure.DecodeHook()))
+//
+// Note that the hook replaces viper's default hooks, which parse
+// time.Duration strings in Go syntax (e.g. "1h30m"), rather than adding to
+// them.
+func DecodeHook(opts ...duration.ParseOption) mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+		s := reflect.ValueOf(data).String()
+
+		switch to {
+		case timeDurationType:
+			return duration.ParseWithOptions(s, opts...)
+		case durationType:
+			d, err := duration.ParseWithOptions(s, opts...)
+			return duration.Duration(d), err
+		case componentsType:
+			return duration.ParseComponents(s, opts...)
+		}
+		return data, nil
+	}
+}
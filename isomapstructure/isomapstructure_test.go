@@ -0,0 +1,80 @@
+package isomapstructure
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type config struct {
+	Timeout  time.Duration
+	Interval duration.Duration
+	Period   duration.Components
+	Retries  int
+	Name     string
+}
+
+func decode(t *testing.T, in map[string]any, opts ...duration.ParseOption) (config, error) {
+	t.Helper()
+
+	var cfg config
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: DecodeHook(opts...),
+		Result:     &cfg,
+	})
+	require.NoError(t, err)
+	return cfg, dec.Decode(in)
+}
+
+func TestDecodeHook(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := decode(t, map[string]any{
+		"timeout":  "PT30S",
+		"interval": "PT1H30M",
+		"period":   "P1Y2M",
+		"retries":  3,
+		"name":     "PT5M",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, config{
+		Timeout:  30 * time.Second,
+		Interval: duration.Duration(90 * time.Minute),
+		Period:   duration.Components{Years: 1, Months: 2},
+		Retries:  3,
+		Name:     "PT5M",
+	}, cfg)
+}
+
+func TestDecodeHookOptions(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := decode(t, map[string]any{"timeout": "-PT5S"}, duration.AllowNegative())
+	require.NoError(t, err)
+	assert.Equal(t, -5*time.Second, cfg.Timeout)
+
+	_, err = decode(t, map[string]any{"timeout": "-PT5S"})
+	assert.ErrorIs(t, err, duration.ErrBadFormat)
+}
+
+func TestDecodeHookErrors(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  map[string]any
+		err error
+	}{
+		{map[string]any{"timeout": "5s"}, duration.ErrBadFormat},
+		{map[string]any{"interval": "P1M"}, duration.ErrNoMonth},
+		{map[string]any{"period": "PT"}, duration.ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		_, err := decode(t, vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
@@ -0,0 +1,52 @@
+// Package isopb converts between ISO8601 durations and the protocol buffers
+// well-known type google.protobuf.Duration, so that gRPC services and the REST
+// gateways in front of them can share a single representation.
+package isopb
+
+import (
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ToProto returns d as a durationpb.Duration.
+func ToProto(d time.Duration) *durationpb.Duration {
+	return durationpb.New(d)
+}
+
+// FromProto returns pb as a time.Duration. It fails with duration.ErrBadFormat
+// if pb is nil or invalid (e.g. its seconds and nanos have different signs),
+// and with duration.ErrRange if pb is valid but too large for a
+// time.Duration.
+func FromProto(pb *durationpb.Duration) (time.Duration, error) {
+	if pb.CheckValid() != nil {
+		return 0, duration.ErrBadFormat
+	}
+
+	d := pb.AsDuration()
+	if n := durationpb.New(d); n.Seconds != pb.Seconds || n.Nanos != pb.Nanos {
+		return 0, duration.ErrRange
+	}
+	return d, nil
+}
+
+// Format returns pb in ISO8601 form, as written by duration.FormatWithOptions
+// with opts (e.g. "PT1H30M").
+func Format(pb *durationpb.Duration, opts ...duration.FormatOption) (string, error) {
+	d, err := FromProto(pb)
+	if err != nil {
+		return "", err
+	}
+	return duration.FormatWithOptions(d, opts...)
+}
+
+// Parse parses an ISO8601 duration, as accepted by duration.ParseWithOptions
+// with opts, into a durationpb.Duration.
+func Parse(s string, opts ...duration.ParseOption) (*durationpb.Duration, error) {
+	d, err := duration.ParseWithOptions(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ToProto(d), nil
+}
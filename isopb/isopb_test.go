@@ -0,0 +1,81 @@
+package isopb
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestToProto(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out *durationpb.Duration
+	}{
+		{0, &durationpb.Duration{}},
+		{90 * time.Minute, &durationpb.Duration{Seconds: 5400}},
+		{1500 * time.Millisecond, &durationpb.Duration{Seconds: 1, Nanos: 500000000}},
+		{-1500 * time.Millisecond, &durationpb.Duration{Seconds: -1, Nanos: -500000000}},
+	}
+
+	for _, vec := range vecs {
+		pb := ToProto(vec.in)
+		assert.Equal(t, vec.out.Seconds, pb.Seconds, vec.in)
+		assert.Equal(t, vec.out.Nanos, pb.Nanos, vec.in)
+
+		d, err := FromProto(pb)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.in, d)
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	bad := []struct {
+		in  *durationpb.Duration
+		err error
+	}{
+		{nil, duration.ErrBadFormat},
+		{&durationpb.Duration{Seconds: 1, Nanos: -1}, duration.ErrBadFormat},
+		{&durationpb.Duration{Nanos: 1e9}, duration.ErrBadFormat},
+		{&durationpb.Duration{Seconds: 1e10}, duration.ErrRange},
+		{&durationpb.Duration{Seconds: -1e10}, duration.ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := FromProto(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	t.Parallel()
+
+	s, err := Format(&durationpb.Duration{Seconds: 5400})
+	assert.NoError(t, err)
+	assert.Equal(t, "PT1H30M", s)
+
+	s, err = Format(&durationpb.Duration{Seconds: 129600}, duration.LargestUnit(duration.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "PT36H", s)
+
+	_, err = Format(&durationpb.Duration{Seconds: -1})
+	assert.ErrorIs(t, err, duration.ErrNoNegative)
+
+	pb, err := Parse("PT1.5S")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pb.Seconds)
+	assert.Equal(t, int32(500000000), pb.Nanos)
+
+	pb, err = Parse("-P1D", duration.AllowNegative())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-86400), pb.Seconds)
+
+	_, err = Parse("P1M")
+	assert.ErrorIs(t, err, duration.ErrNoMonth)
+}
@@ -0,0 +1,82 @@
+// Package isovalidator adds ISO8601 duration tags to
+// github.com/go-playground/validator/v10, so that request structs can
+// validate client-supplied durations declaratively:
+//
+//	type Request struct {
+//		Timeout string `validate:"iso8601dur,iso8601dur_min=PT1S,iso8601dur_max=PT1H"`
+//	}
+package isovalidator
+
+import (
+	"reflect"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/go-playground/validator/v10"
+)
+
+// Tags registered by Register.
+const (
+	// Tag checks that a string field is an ISO8601 duration.
+	Tag = "iso8601dur"
+
+	// MinTag checks that a duration is at least its parameter (e.g.
+	// "iso8601dur_min=PT1S").
+	MinTag = "iso8601dur_min"
+
+	// MaxTag checks that a duration is at most its parameter (e.g.
+	// "iso8601dur_max=PT1H").
+	MaxTag = "iso8601dur_max"
+)
+
+var (
+	timeDurationType = reflect.TypeOf(time.Duration(0))
+	durationType     = reflect.TypeOf(duration.Duration(0))
+)
+
+// Register adds Tag, MinTag and MaxTag to v. Each applies to string fields,
+// which are parsed with duration.ParseWithOptions and opts, and to
+// time.Duration and duration.Duration fields. An invalid string fails every
+// tag. Like validator's own tags, MinTag and MaxTag panic if their parameter
+// is not a valid duration.
+func Register(v *validator.Validate, opts ...duration.ParseOption) error {
+	parse := func(fl validator.FieldLevel) (time.Duration, bool) {
+		f := fl.Field()
+		switch {
+		case f.Kind() == reflect.String:
+			d, err := duration.ParseWithOptions(f.String(), opts...)
+			return d, err == nil
+		case f.Type() == timeDurationType || f.Type() == durationType:
+			return time.Duration(f.Int()), true
+		}
+		return 0, false
+	}
+	param := func(fl validator.FieldLevel) time.Duration {
+		d, err := duration.ParseWithOptions(fl.Param(), opts...)
+		if err != nil {
+			panic("isovalidator: bad parameter " + fl.Param() + " for " + fl.GetTag() + ": " + err.Error())
+		}
+		return d
+	}
+
+	funcs := map[string]validator.Func{
+		Tag: func(fl validator.FieldLevel) bool {
+			_, ok := parse(fl)
+			return ok
+		},
+		MinTag: func(fl validator.FieldLevel) bool {
+			d, ok := parse(fl)
+			return ok && d >= param(fl)
+		},
+		MaxTag: func(fl validator.FieldLevel) bool {
+			d, ok := parse(fl)
+			return ok && d <= param(fl)
+		},
+	}
+	for tag, fn := range funcs {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
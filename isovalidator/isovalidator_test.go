@@ -0,0 +1,81 @@
+package isovalidator
+
+import (
+	"testing"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidate(t *testing.T, opts ...duration.ParseOption) *validator.Validate {
+	t.Helper()
+
+	v := validator.New()
+	require.NoError(t, Register(v, opts...))
+	return v
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Timeout  string            `validate:"iso8601dur,iso8601dur_min=PT1S,iso8601dur_max=PT1H"`
+		Interval time.Duration     `validate:"iso8601dur_min=PT1M"`
+		Delay    duration.Duration `validate:"iso8601dur_max=PT10S"`
+	}
+
+	vecs := []struct {
+		in  request
+		tag string
+	}{
+		{request{"PT30S", time.Minute, 0}, ""},
+		{request{"PT1S", time.Hour, duration.Duration(10 * time.Second)}, ""},
+		{request{"PT1H", time.Minute, 0}, ""},
+		{request{"30s", time.Minute, 0}, Tag},
+		{request{"", time.Minute, 0}, Tag},
+		{request{"PT0.5S", time.Minute, 0}, MinTag},
+		{request{"PT1H1S", time.Minute, 0}, MaxTag},
+		{request{"PT30S", time.Second, 0}, MinTag},
+		{request{"PT30S", time.Minute, duration.Duration(time.Minute)}, MaxTag},
+	}
+
+	v := newValidate(t)
+	for _, vec := range vecs {
+		err := v.Struct(vec.in)
+		if vec.tag == "" {
+			assert.NoError(t, err, vec.in)
+			continue
+		}
+
+		var errs validator.ValidationErrors
+		if assert.ErrorAs(t, err, &errs, vec.in) {
+			assert.Equal(t, vec.tag, errs[0].Tag(), vec.in)
+		}
+	}
+}
+
+func TestRegisterOptions(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Offset string `validate:"iso8601dur_min=-PT1H"`
+	}
+
+	v := newValidate(t, duration.AllowNegative())
+	assert.NoError(t, v.Struct(request{"-PT30M"}))
+	assert.Error(t, v.Struct(request{"-PT2H"}))
+}
+
+func TestRegisterBadParam(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Timeout string `validate:"iso8601dur_max=1h"`
+	}
+
+	v := newValidate(t)
+	assert.Panics(t, func() { _ = v.Struct(request{"PT1M"}) })
+}
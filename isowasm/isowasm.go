@@ -0,0 +1,115 @@
+//go:build js && wasm
+
+// Package isowasm exposes the parsing, formatting and validation of ISO8601
+// durations to JavaScript when built with GOOS=js GOARCH=wasm, so that a
+// browser checks durations with exactly the rules the server applies. A
+// program built for the browser registers the functions and then blocks:
+//
+//	func main() {
+//		obj := js.Global().Get("Object").New()
+//		isowasm.Register(obj)
+//		js.Global().Set("iso8601duration", obj)
+//		select {}
+//	}
+//
+// Each function takes an optional last argument naming a profile, as
+// registered with duration.RegisterProfile, whose rules replace the defaults
+// of duration.Parse and duration.Format (e.g.
+// iso8601duration.validate("PT1H", "youtube")).
+package isowasm
+
+import (
+	"errors"
+	"math"
+	"syscall/js"
+	"time"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+)
+
+// ErrNoProfile is returned for a profile name that is not registered.
+var ErrNoProfile = errors.New("unknown profile")
+
+// Register sets the following functions on obj:
+//
+//   - parse(s, [profile]) returns {value, error}, where value is the duration
+//     in milliseconds, the unit of JavaScript dates, with any fraction kept.
+//   - format(ms, [profile]) returns {value, error}, where value is the
+//     ISO8601 form of a duration in milliseconds.
+//   - validate(s, [profile]) returns null if s is valid and the error message
+//     otherwise.
+//
+// The error member is null on success and the error message otherwise, with
+// value set to null.
+func Register(obj js.Value) {
+	obj.Set("parse", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		d, err := parse(args)
+		if err != nil {
+			return result(nil, err)
+		}
+		return result(float64(d)/float64(time.Millisecond), nil)
+	}))
+	obj.Set("format", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		s, err := format(args)
+		if err != nil {
+			return result(nil, err)
+		}
+		return result(s, nil)
+	}))
+	obj.Set("validate", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if _, err := parse(args); err != nil {
+			return err.Error()
+		}
+		return nil
+	}))
+}
+
+// profile returns the profile named by the optional argument after the n
+// required ones, or the defaults of duration.Parse and duration.Format.
+func profile(args []js.Value, n int) (duration.Profile, error) {
+	if len(args) <= n || args[n].IsUndefined() || args[n].IsNull() {
+		return duration.Profile{}, nil
+	}
+	if args[n].Type() != js.TypeString {
+		return duration.Profile{}, ErrNoProfile
+	}
+	p, ok := duration.LookupProfile(args[n].String())
+	if !ok {
+		return duration.Profile{}, ErrNoProfile
+	}
+	return p, nil
+}
+
+func parse(args []js.Value) (time.Duration, error) {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return 0, duration.ErrBadFormat
+	}
+	p, err := profile(args, 1)
+	if err != nil {
+		return 0, err
+	}
+	return p.Parse(args[0].String())
+}
+
+func format(args []js.Value) (string, error) {
+	if len(args) == 0 || args[0].Type() != js.TypeNumber {
+		return "", duration.ErrBadFormat
+	}
+	p, err := profile(args, 1)
+	if err != nil {
+		return "", err
+	}
+	f := math.Round(args[0].Float() * float64(time.Millisecond))
+	if f >= math.MaxInt64 || f < math.MinInt64 || math.IsNaN(f) {
+		return "", duration.ErrRange
+	}
+	return p.Format(time.Duration(f))
+}
+
+// result returns the {value, error} object of parse and format.
+func result(v any, err error) map[string]any {
+	if err != nil {
+		return map[string]any{"value": nil, "error": err.Error()}
+	}
+	return map[string]any{"value": v, "error": nil}
+}
@@ -0,0 +1,98 @@
+//go:build js && wasm
+
+package isowasm
+
+import (
+	"syscall/js"
+	"testing"
+
+	duration "github.com/SpirentOrion/iso8601duration.v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newObject() js.Value {
+	obj := js.Global().Get("Object").New()
+	Register(obj)
+	return obj
+}
+
+// goValue converts the null, number and string values returned by the
+// registered functions to Go.
+func goValue(v js.Value) any {
+	switch v.Type() {
+	case js.TypeNumber:
+		return v.Float()
+	case js.TypeString:
+		return v.String()
+	}
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	obj := newObject()
+	vecs := []struct {
+		args []any
+		out  any
+		err  error
+	}{
+		{[]any{"PT1H30M"}, 5400000.0, nil},
+		{[]any{"PT0.0005S"}, 0.5, nil},
+		{[]any{"PT1H", "youtube"}, 3600000.0, nil},
+		{[]any{"PT1H", nil}, 3600000.0, nil},
+		{[]any{"P1M"}, nil, duration.ErrNoMonth},
+		{[]any{"PT1.5S", "youtube"}, nil, duration.ErrBadFormat},
+		{[]any{"PT1H", "nope"}, nil, ErrNoProfile},
+		{[]any{5}, nil, duration.ErrBadFormat},
+		{nil, nil, duration.ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		r := obj.Call("parse", vec.args...)
+		assert.Equal(t, vec.out, goValue(r.Get("value")), vec.args)
+		if vec.err == nil {
+			assert.Nil(t, goValue(r.Get("error")), vec.args)
+		} else {
+			assert.Contains(t, r.Get("error").String(), vec.err.Error(), vec.args)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	obj := newObject()
+	vecs := []struct {
+		args []any
+		out  any
+		err  error
+	}{
+		{[]any{5400000}, "PT1H30M", nil},
+		{[]any{1.5}, "PT0.001500S", nil},
+		{[]any{0, "rfc3339"}, "PT0S", nil},
+		{[]any{-1000}, nil, duration.ErrNoNegative},
+		{[]any{1e300}, nil, duration.ErrRange},
+		{[]any{"PT1H"}, nil, duration.ErrBadFormat},
+		{[]any{1000, "nope"}, nil, ErrNoProfile},
+	}
+
+	for _, vec := range vecs {
+		r := obj.Call("format", vec.args...)
+		assert.Equal(t, vec.out, goValue(r.Get("value")), vec.args)
+		if vec.err == nil {
+			assert.Nil(t, goValue(r.Get("error")), vec.args)
+		} else {
+			assert.Contains(t, r.Get("error").String(), vec.err.Error(), vec.args)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	obj := newObject()
+	assert.Nil(t, goValue(obj.Call("validate", "P1DT2H")))
+	assert.Nil(t, goValue(obj.Call("validate", "-PT1H", "xsd")))
+	assert.Equal(t, duration.ErrBadFormat.Error(), goValue(obj.Call("validate", "-PT1H")))
+}
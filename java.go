@@ -0,0 +1,70 @@
+package duration
+
+import (
+	"strconv"
+	"time"
+)
+
+type javaOption struct{}
+
+func (javaOption) applyParse(c *parseConfig) {
+	c.negative, c.signed, c.mixedWeeks = true, true, true
+}
+
+func (javaOption) applyFormat(c *formatConfig) { c.java = true }
+
+// JavaCompatible matches the ISO8601 dialect of java.time.Duration and
+// java.time.Period, whose elements carry their own signs (e.g. "PT-6H+3M" or
+// "P-1Y2M").
+//
+// When parsing, a sign is accepted on each element as well as ahead of the
+// whole duration, which negates all elements (e.g. "-PT-6H+3M" is 5 hours 57
+// minutes), and weeks may be combined with other elements as by MixedWeeks
+// (e.g. "P1Y2M3W4D").
+//
+// When formatting, the output is that of Duration.toString: hours, minutes and
+// seconds only, each with its own sign, trailing zeros removed from seconds,
+// and "PT0S" for zero (e.g. "PT-1H-30M"). Other format options are ignored,
+// except by FormatComponents, which writes each field with its own sign like
+// Period.toString; pass ZeroAs(ZeroDays) to match the "P0D" Java writes for
+// an empty Period.
+func JavaCompatible() Option {
+	return javaOption{}
+}
+
+// appendJava appends d as written by java.time.Duration.toString.
+func appendJava(b []byte, d time.Duration) []byte {
+	if d == 0 {
+		return append(b, "PT0S"...)
+	}
+	b = append(b, "PT"...)
+
+	if h := d / time.Hour; h != 0 {
+		b = strconv.AppendInt(b, int64(h), 10)
+		b = append(b, 'H')
+	}
+	if m := d % time.Hour / time.Minute; m != 0 {
+		b = strconv.AppendInt(b, int64(m), 10)
+		b = append(b, 'M')
+	}
+
+	s := d % time.Minute
+	if s == 0 {
+		return b
+	}
+	if s < 0 {
+		b = append(b, '-')
+		s = -s
+	}
+	b = strconv.AppendInt(b, int64(s/time.Second), 10)
+	if frac := s % time.Second; frac != 0 {
+		digits := 9
+		for frac%10 == 0 {
+			frac /= 10
+			digits--
+		}
+		b = append(b, '.')
+		b = appendPadded(b, int64(frac), digits)
+	}
+	return append(b, 'S')
+}
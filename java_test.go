@@ -0,0 +1,99 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithOptionsGivenJavaCompatible(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"PT8H6M12.345S", 8*time.Hour + 6*time.Minute + 12345*time.Millisecond},
+		{"PT-6H+3M", -6*time.Hour + 3*time.Minute},
+		{"-PT6H3M", -6*time.Hour - 3*time.Minute},
+		{"-PT-6H+3M", 6*time.Hour - 3*time.Minute},
+		{"+PT1H", time.Hour},
+		{"PT-0.5S", -500 * time.Millisecond},
+		{"P2DT3H", 2*dayTime + 3*time.Hour},
+		{"P-2D", -2 * dayTime},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseWithOptions(vec.in, JavaCompatible())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	for _, in := range []string{"PT-6H+3M", "+PT1H", "PT+1H"} {
+		_, err := ParseWithOptions(in, AllowNegative())
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+	for _, in := range []string{"P--1D", "PT-H", "-+PT1H", "PT1H-", "P-T1H"} {
+		_, err := ParseWithOptions(in, JavaCompatible())
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+}
+
+func TestFormatWithOptionsGivenJavaCompatible(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{0, "PT0S"},
+		{8*time.Hour + 6*time.Minute + 12345*time.Millisecond, "PT8H6M12.345S"},
+		{-6*time.Hour + 3*time.Minute, "PT-5H-57M"},
+		{-90 * time.Minute, "PT-1H-30M"},
+		{2*dayTime + 3*time.Hour, "PT51H"},
+		{-1500 * time.Millisecond, "PT-1.5S"},
+		{-500 * time.Millisecond, "PT-0.5S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{time.Duration(math.MinInt64), "PT-2562047H-47M-16.854775808S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatWithOptions(vec.in, JavaCompatible(), Precision(3), UseWeeks())
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		d, err := ParseWithOptions(s, JavaCompatible())
+		assert.NoError(t, err, s)
+		assert.Equal(t, vec.in, d, s)
+	}
+}
+
+func TestComponentsGivenJavaCompatible(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParseComponents("P-1Y2M", JavaCompatible())
+	assert.NoError(t, err)
+	assert.Equal(t, Components{Years: -1, Months: 2}, c)
+
+	c, err = ParseComponents("-P1Y-2M3W", JavaCompatible())
+	assert.NoError(t, err)
+	assert.Equal(t, Components{Years: -1, Months: 2, Weeks: -3}, c)
+
+	vecs := []struct {
+		in  Components
+		out string
+	}{
+		{Components{Years: -1, Months: 2}, "P-1Y2M"},
+		{Components{Years: 1, Months: 2, Days: 3}, "P1Y2M3D"},
+		{Components{Days: -3}, "P-3D"},
+		{Components{}, "P0D"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatComponents(vec.in, JavaCompatible(), ZeroAs(ZeroDays))
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
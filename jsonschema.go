@@ -0,0 +1,67 @@
+package duration
+
+import "strings"
+
+// IsJSONSchemaDuration reports whether v satisfies the "duration" format of
+// JSON Schema, which is the grammar of RFC 3339 Appendix A: integer elements
+// only, no sign, weeks on their own, and no gaps between the elements written
+// (e.g. "P1Y2M" and "PT1M30S" but not "P1Y2D" or "PT1.5S"). As the format
+// applies only to strings, any other value is valid. The signature matches
+// the format functions of github.com/santhosh-tekuri/jsonschema/v5.
+//
+// Every string accepted is also accepted by ParseComponents, so that a value
+// that passed schema validation parses at run time; Parse additionally
+// rejects month elements.
+func IsJSONSchemaDuration(v any) bool {
+	s, ok := v.(string)
+	return !ok || rfc3339Duration(s, "YMD")
+}
+
+// JSONSchemaFormat is a format checker for github.com/xeipuuv/gojsonschema,
+// to be registered as gojsonschema.FormatCheckers.Add("duration",
+// JSONSchemaFormat{}). It accepts the values IsJSONSchemaDuration does.
+type JSONSchemaFormat struct{}
+
+// IsFormat reports whether input is valid as by IsJSONSchemaDuration.
+func (JSONSchemaFormat) IsFormat(input any) bool {
+	return IsJSONSchemaDuration(input)
+}
+
+// rfc3339Duration reports whether s is an unsigned duration in the grammar of
+// RFC 3339 Appendix A, with the date part restricted to the designators in
+// dateUnits.
+func rfc3339Duration(s, dateUnits string) bool {
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok || s == "" {
+		return false
+	}
+	date, tm, hasTime := strings.Cut(s, "T")
+	if !hasTime && strings.HasSuffix(date, "W") {
+		return integerElements(date, "W", false)
+	}
+	return integerElements(date, dateUnits, false) && (!hasTime || tm != "" && integerElements(tm, "HMS", false))
+}
+
+// integerElements reports whether s is a run of integer elements whose
+// designators are letters of units in order, with none skipped unless gaps is
+// set.
+func integerElements(s, units string, gaps bool) bool {
+	next := -1
+	for s != "" {
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return false
+		}
+
+		u := strings.IndexByte(units, s[i])
+		if u == -1 || u < next || !gaps && next != -1 && u != next {
+			return false
+		}
+		next = u + 1
+		s = s[i+1:]
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsJSONSchemaDuration(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in    any
+		valid bool
+	}{
+		{"P4DT12H30M5S", true},
+		{"P1Y2M3DT4H5M6S", true},
+		{"P1Y2M", true},
+		{"P2M1D", true},
+		{"P0D", true},
+		{"PT36H", true},
+		{"PT1M30S", true},
+		{"PT0S", true},
+		{"P4W", true},
+		{"P1DT1M", true},
+		{12, true},
+		{nil, true},
+
+		{"", false},
+		{"P", false},
+		{"PT", false},
+		{"P1DT", false},
+		{"P1", false},
+		{"1D", false},
+		{"PT1D", false},
+		{"P2D1Y", false},
+		{"P1Y2D", false},
+		{"PT1H1S", false},
+		{"PT1.5S", false},
+		{"PT1,5S", false},
+		{"-P1D", false},
+		{"P-1D", false},
+		{"P1W1D", false},
+		{"P1D1W", false},
+		{"P1WT1H", false},
+		{"P1DT1H2T", false},
+		{"p1d", false},
+		{" P1D", false},
+		{"P١D", false},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.valid, IsJSONSchemaDuration(vec.in), "%v", vec.in)
+		assert.Equal(t, vec.valid, JSONSchemaFormat{}.IsFormat(vec.in), "%v", vec.in)
+
+		if s, ok := vec.in.(string); ok && vec.valid {
+			_, err := ParseComponents(s)
+			assert.NoError(t, err, s)
+		}
+	}
+}
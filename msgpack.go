@@ -0,0 +1,131 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// MessagePack format bytes used by the msgpack methods.
+const (
+	msgpackFixStr  = 0xa0
+	msgpackNil     = 0xc0
+	msgpackFloat32 = 0xca
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackUint16  = 0xcd
+	msgpackUint32  = 0xce
+	msgpackUint64  = 0xcf
+	msgpackInt8    = 0xd0
+	msgpackInt16   = 0xd1
+	msgpackInt32   = 0xd2
+	msgpackInt64   = 0xd3
+	msgpackStr8    = 0xd9
+	msgpackStr16   = 0xda
+	msgpackStr32   = 0xdb
+)
+
+// MarshalMsgpack implements the Marshaler interface of
+// github.com/vmihailenco/msgpack, encoding d as an ISO8601 string. The same
+// encoding is used for the payload when Duration is registered as an
+// extension type with msgpack.RegisterExt.
+func (d Duration) MarshalMsgpack() ([]byte, error) {
+	var buf [32]byte
+	s, err := signedFormat.append(buf[:0], time.Duration(d))
+	if err != nil {
+		return nil, err
+	}
+
+	// Formatted durations are always short enough for a fixstr
+	b := make([]byte, 0, len(s)+1)
+	b = append(b, msgpackFixStr|byte(len(s)))
+	return append(b, s...), nil
+}
+
+// UnmarshalMsgpack implements the Unmarshaler interface of
+// github.com/vmihailenco/msgpack. It accepts an ISO8601 string, or an integer
+// or float number of seconds. A msgpack nil leaves d unchanged.
+func (d *Duration) UnmarshalMsgpack(b []byte) error {
+	if len(b) == 0 {
+		return ErrBadFormat
+	}
+
+	c, b := b[0], b[1:]
+	var size int
+	switch {
+	case c == msgpackNil && len(b) == 0:
+		return nil
+	case c <= 0x7f:
+		return d.setSeconds(float64(c), b)
+	case c >= 0xe0:
+		return d.setSeconds(float64(int8(c)), b)
+	case c&0xe0 == msgpackFixStr:
+		return d.setString(b, int(c&0x1f))
+	case c == msgpackStr8, c == msgpackUint8, c == msgpackInt8:
+		size = 1
+	case c == msgpackStr16, c == msgpackUint16, c == msgpackInt16:
+		size = 2
+	case c == msgpackStr32, c == msgpackUint32, c == msgpackInt32, c == msgpackFloat32:
+		size = 4
+	case c == msgpackUint64, c == msgpackInt64, c == msgpackFloat64:
+		size = 8
+	default:
+		return ErrBadFormat
+	}
+	if len(b) < size {
+		return ErrBadFormat
+	}
+
+	var n uint64
+	for _, x := range b[:size] {
+		n = n<<8 | uint64(x)
+	}
+	b = b[size:]
+
+	switch c {
+	case msgpackStr8, msgpackStr16, msgpackStr32:
+		return d.setString(b, int(n))
+	case msgpackUint8, msgpackUint16, msgpackUint32, msgpackUint64:
+		if n > math.MaxInt64 {
+			return ErrRange
+		}
+		return d.setSeconds(float64(n), b)
+	case msgpackInt8:
+		return d.setSeconds(float64(int8(n)), b)
+	case msgpackInt16:
+		return d.setSeconds(float64(int16(n)), b)
+	case msgpackInt32:
+		return d.setSeconds(float64(int32(n)), b)
+	case msgpackInt64:
+		return d.setSeconds(float64(int64(n)), b)
+	case msgpackFloat32:
+		return d.setSeconds(float64(math.Float32frombits(uint32(n))), b)
+	}
+	return d.setSeconds(math.Float64frombits(n), b)
+}
+
+// setString parses the n-byte string at the start of b, which must hold
+// nothing else, into d.
+func (d *Duration) setString(b []byte, n int) error {
+	if len(b) != n {
+		return ErrBadFormat
+	}
+	v, err := parseSigned(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// setSeconds sets d to secs seconds, provided that rest is empty.
+func (d *Duration) setSeconds(secs float64, rest []byte) error {
+	if len(rest) != 0 {
+		return ErrBadFormat
+	}
+	v, err := fromSeconds(secs)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
@@ -0,0 +1,74 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationMarshalMsgpack(t *testing.T) {
+	t.Parallel()
+
+	b, err := Duration(90 * time.Minute).MarshalMsgpack()
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0xa7}, "PT1H30M"...), b)
+
+	b, err = Duration(math.MinInt64 + 1).MarshalMsgpack()
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0xbe}, "-P292Y171DT23H47M16.854775807S"...), b)
+
+	_, err = Duration(math.MinInt64).MarshalMsgpack()
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestDurationUnmarshalMsgpack(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  []byte
+		out Duration
+	}{
+		{append([]byte{0xa7}, "PT1H30M"...), Duration(90 * time.Minute)},
+		{append([]byte{0xd9, 5}, "-PT1S"...), Duration(-time.Second)},
+		{append([]byte{0xda, 0, 4}, "PT1M"...), Duration(time.Minute)},
+		{append([]byte{0xdb, 0, 0, 0, 4}, "PT1M"...), Duration(time.Minute)},
+		{[]byte{0x05}, Duration(5 * time.Second)},
+		{[]byte{0xff}, Duration(-time.Second)},
+		{[]byte{0xcd, 0x15, 0x18}, Duration(90 * time.Minute)},
+		{[]byte{0xd0, 0x80}, Duration(-128 * time.Second)},
+		{[]byte{0xd1, 0xff, 0xfe}, Duration(-2 * time.Second)},
+		{[]byte{0xd3, 0, 0, 0, 0, 0, 0, 0, 1}, Duration(time.Second)},
+		{[]byte{0xca, 0x3f, 0x00, 0x00, 0x00}, Duration(500 * time.Millisecond)},
+		{[]byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, Duration(1500 * time.Millisecond)},
+		{[]byte{0xc0}, Duration(time.Minute)},
+	}
+
+	for _, vec := range vecs {
+		d := Duration(time.Minute)
+		assert.NoError(t, d.UnmarshalMsgpack(vec.in), vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  []byte
+		err error
+	}{
+		{nil, ErrBadFormat},
+		{[]byte{0xa8, 'P'}, ErrBadFormat},
+		{append([]byte{0xa2}, "1h"...), ErrBadFormat},
+		{[]byte{0xcd, 0x15}, ErrBadFormat},
+		{[]byte{0x05, 0x05}, ErrBadFormat},
+		{[]byte{0xc0, 0x00}, ErrBadFormat},
+		{[]byte{0xc3}, ErrBadFormat},
+		{[]byte{0x90}, ErrBadFormat},
+		{[]byte{0xcf, 0xff, 0, 0, 0, 0, 0, 0, 0}, ErrRange},
+		{[]byte{0xcb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 1}, ErrRange},
+	}
+
+	for _, vec := range bad {
+		var d Duration
+		assert.ErrorIs(t, d.UnmarshalMsgpack(vec.in), vec.err, vec.in)
+	}
+}
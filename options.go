@@ -0,0 +1,425 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// A ParseOption adjusts the grammar accepted by ParseWithOptions.
+type ParseOption interface {
+	applyParse(*parseConfig)
+}
+
+// An Option adjusts both ParseWithOptions and FormatWithOptions.
+type Option interface {
+	ParseOption
+	FormatOption
+}
+
+type parseConfig struct {
+	trimMode   TrimMode
+	sep        Separator
+	outOfOrder bool
+	repeated   bool
+	noYears    bool
+	months     bool
+	negative   bool
+	mixedWeeks bool
+	signed     bool
+}
+
+func newParseConfig(opts []ParseOption) *parseConfig {
+	c := new(parseConfig)
+	for _, opt := range opts {
+		opt.applyParse(c)
+	}
+	return c
+}
+
+func (c *parseConfig) trim(s string) string {
+	switch c.trimMode {
+	case TrimNone:
+		return s
+	case TrimLineEnding:
+		if strings.HasSuffix(s, "\r\n") {
+			return s[:len(s)-2]
+		}
+		return strings.TrimSuffix(s, "\n")
+	}
+	return strings.TrimSpace(s)
+}
+
+type parseOptionFunc func(*parseConfig)
+
+func (f parseOptionFunc) applyParse(c *parseConfig) { f(c) }
+
+// TrimMode selects how surrounding whitespace is handled before parsing.
+type TrimMode int
+
+const (
+	// TrimSpace removes all leading and trailing white space. This is the
+	// default.
+	TrimSpace TrimMode = iota
+
+	// TrimNone accepts no surrounding white space at all.
+	TrimNone
+
+	// TrimLineEnding removes a single trailing "\n" or "\r\n", as read from
+	// line-based protocols, and accepts no other white space.
+	TrimLineEnding
+)
+
+// Trim selects how surrounding white space is handled. Input rejected because
+// of white space fails with ErrBadFormat.
+func Trim(mode TrimMode) ParseOption {
+	return parseOptionFunc(func(c *parseConfig) { c.trimMode = mode })
+}
+
+// Separator selects the decimal separator used in fractional elements.
+type Separator byte
+
+const (
+	// SeparatorAny accepts either "." or "," when parsing. This is the
+	// default.
+	SeparatorAny Separator = 0
+
+	// SeparatorDot accepts only "." (e.g. "PT1.5S").
+	SeparatorDot Separator = '.'
+
+	// SeparatorComma accepts only "," (e.g. "PT1,5S"), the separator preferred
+	// by ISO8601.
+	SeparatorComma Separator = ','
+)
+
+type separatorOption Separator
+
+func (o separatorOption) applyParse(c *parseConfig)   { c.sep = Separator(o) }
+func (o separatorOption) applyFormat(c *formatConfig) { c.sep = Separator(o) }
+
+// DecimalSeparator selects the decimal separator used in fractional elements.
+// When parsing, fractions using any other separator fail with ErrBadFormat.
+// When formatting, SeparatorComma writes "," and the others write ".".
+func DecimalSeparator(sep Separator) Option {
+	return separatorOption(sep)
+}
+
+type negativeOption struct{}
+
+func (negativeOption) applyParse(c *parseConfig)   { c.negative = true }
+func (negativeOption) applyFormat(c *formatConfig) { c.negative = true }
+
+// AllowNegative enables negative durations, written with a single leading
+// "-" sign ahead of otherwise positive elements (e.g. "-P1DT2H"), as in XML
+// Schema's xs:duration. Without it, parsing a sign fails with ErrBadFormat and
+// formatting a negative duration fails with ErrNoNegative.
+func AllowNegative() Option {
+	return negativeOption{}
+}
+
+type mixedWeeksOption struct{}
+
+func (mixedWeeksOption) applyParse(c *parseConfig)   { c.mixedWeeks = true }
+func (mixedWeeksOption) applyFormat(c *formatConfig) { c.mixedWeeks = true }
+
+// MixedWeeks allows week elements alongside other elements (e.g. "P2W3D"), as
+// permitted by ISO 8601-2 but not by ISO 8601-1. When parsing, such strings
+// are accepted rather than rejected with ErrBadFormat. When formatting, whole
+// weeks are written as a week element ahead of the remaining days.
+func MixedWeeks() Option {
+	return mixedWeeksOption{}
+}
+
+// AllowOutOfOrder accepts elements in any order within the date and time parts
+// of a duration (e.g. "PT5S1H"), summing them as usual. The "T" separator is
+// still required ahead of any time elements.
+func AllowOutOfOrder() ParseOption {
+	return parseOptionFunc(func(c *parseConfig) { c.outOfOrder = true })
+}
+
+// AllowRepeated accepts designators that appear more than once (e.g.
+// "PT1H1H30M"), summing their values. Without it such strings are rejected
+// with ErrDuplicate.
+func AllowRepeated() ParseOption {
+	return parseOptionFunc(func(c *parseConfig) { c.repeated = true })
+}
+
+// RejectYears rejects year elements with ErrNoYear. Like months, years vary in
+// length, so services that need exact durations may prefer not to accept them.
+func RejectYears() ParseOption {
+	return parseOptionFunc(func(c *parseConfig) { c.noYears = true })
+}
+
+// A FormatOption adjusts the output of FormatWithOptions.
+type FormatOption interface {
+	applyFormat(*formatConfig)
+}
+
+type formatConfig struct {
+	weeks     bool
+	zero      Zero
+	sep       Separator
+	digits    int
+	trimZeros bool
+	largest   Unit
+	smallest  Unit
+	rounding  *RoundingMode
+	single    bool
+
+	maxComponents int
+	labels        map[Unit]string
+	fixed         bool
+	allZeros      bool
+	negative      bool
+	speller       Speller
+	mixedWeeks    bool
+	java          bool
+	noGaps        bool
+}
+
+// defaultFormat is the configuration used by Format.
+var defaultFormat = formatConfig{digits: -1, largest: Year, smallest: Second}
+
+func newFormatConfig(opts []FormatOption) *formatConfig {
+	c := new(formatConfig)
+	*c = defaultFormat
+	for _, opt := range opts {
+		opt.applyFormat(c)
+	}
+	if c.smallest < c.largest {
+		c.largest = c.smallest
+	}
+	return c
+}
+
+func (c *formatConfig) separator() byte {
+	if c.sep == SeparatorComma {
+		return ','
+	}
+	return '.'
+}
+
+type formatOptionFunc func(*formatConfig)
+
+func (f formatOptionFunc) applyFormat(c *formatConfig) { f(c) }
+
+// UseWeeks formats durations that are an exact multiple of a week using a
+// single week element (e.g. "P2W"). Other durations are unaffected.
+func UseWeeks() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.weeks = true })
+}
+
+// Zero is the representation of a zero-length duration.
+type Zero string
+
+const (
+	// ZeroYears is "P0Y", the representation used by Format.
+	ZeroYears Zero = "P0Y"
+
+	// ZeroDays is "P0D".
+	ZeroDays Zero = "P0D"
+
+	// ZeroSeconds is "PT0S", the form used by most other implementations
+	// (e.g. XML Schema canonical values and java.time.Duration).
+	ZeroSeconds Zero = "PT0S"
+)
+
+// ZeroAs selects the representation of a zero-length duration.
+func ZeroAs(z Zero) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.zero = z })
+}
+
+// Precision rounds the duration to n fractional digits of the smallest unit
+// (seconds by default) and always writes exactly n digits in that element
+// (e.g. "PT1.500S" for n=3). n is clamped to the range 0 to 9.
+func Precision(n int) FormatOption {
+	if n < 0 {
+		n = 0
+	} else if n > 9 {
+		n = 9
+	}
+	return formatOptionFunc(func(c *formatConfig) { c.digits = n })
+}
+
+// TrimZeros removes trailing zeros from the fraction in the smallest element,
+// dropping the separator too when nothing remains (e.g. "PT1.5S" rather than
+// "PT1.500S"). It may be combined with Precision to limit the digits written.
+func TrimZeros() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.trimZeros = true })
+}
+
+// formatUnit maps u onto the units written by FormatWithOptions.
+func formatUnit(u Unit) Unit {
+	switch {
+	case u < Year:
+		return Year
+	case u == Month || u == Week:
+		return Day
+	case u > Second:
+		return Second
+	}
+	return u
+}
+
+// LargestUnit sets the most significant unit written; larger amounts are
+// carried into it (e.g. "PT36H" rather than "P1DT12H" for Hour). Month and Week
+// are treated as Day. The default is Year.
+func LargestUnit(u Unit) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.largest = formatUnit(u) })
+}
+
+// SmallestUnit sets the least significant unit written. Any remainder is
+// written as a decimal fraction of that unit (e.g. "PT1H30.75M" for Minute)
+// unless Rounding is also given. Month and Week are treated as Day. The
+// default is Second.
+func SmallestUnit(u Unit) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.smallest = formatUnit(u) })
+}
+
+// Rounding rounds the duration to a whole multiple of the smallest unit using
+// mode, so that no fraction is written.
+func Rounding(mode RoundingMode) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.rounding = &mode })
+}
+
+// SingleUnit writes the duration as a single element with a decimal fraction,
+// using the largest unit it holds at least one of (e.g. "PT1.5H" or "P2.25D").
+// LargestUnit caps the unit chosen; to always use one particular unit, set
+// LargestUnit and SmallestUnit to it instead.
+func SingleUnit() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.single = true })
+}
+
+// SecondsOnly writes the duration as a single seconds element (e.g. "PT5400S"
+// for 90 minutes). It is shorthand for LargestUnit(Second).
+func SecondsOnly() FormatOption {
+	return LargestUnit(Second)
+}
+
+// MaxComponents keeps only the n most significant non-zero elements, e.g.
+// "P1Y10D" rather than "P1Y10DT1H1M1.001S" for n=2. The remainder is written
+// as a decimal fraction of the last element kept (e.g. "PT1.5H" rather than
+// "PT1H30M" for n=1) unless Rounding is also given. Zero or negative n means
+// no limit.
+func MaxComponents(n int) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.maxComponents = n })
+}
+
+// UnitLabels replaces the unit abbreviations used by HumanizeCompact, e.g.
+// {Minute: "min"} to write "90min" rather than "90m". Units missing from labels
+// keep their default abbreviation.
+func UnitLabels(labels map[Unit]string) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.labels = labels })
+}
+
+// FixedWidth writes every element zero-padded to a fixed width, in the form
+// "P0000Y000DT00H00M00.000S", so that formatted durations sort in the same
+// order as their values. Seconds have three fractional digits unless Precision
+// says otherwise. Durations of 10,000 years or more overflow the year element.
+// Options that choose which elements to write have no effect.
+func FixedWidth() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.fixed = true })
+}
+
+// IncludeZeros writes every element between the largest and smallest units,
+// including zero elements and a zero month (e.g. "P0Y0M0DT0H0M5S"), for
+// consumers that require all fields to be present.
+func IncludeZeros() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.allZeros = true })
+}
+
+// NoGaps writes zero elements between non-zero ones within the date and time
+// parts, so that no element is skipped (e.g. "PT1H0M1S" rather than "PT1H1S",
+// and "P1Y0M2D" rather than "P1Y2D"), as required by the grammars of RFC 3339
+// Appendix A and RFC 5545. Parse rejects the zero month written between years
+// and days, though ParseComponents accepts it; add LargestUnit(Day) to write
+// years as days instead.
+func NoGaps() FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.noGaps = true })
+}
+
+// An IntervalOption adjusts the intervals accepted by ParseInterval and
+// ParseRepeatingInterval, for example to restrict those received from
+// untrusted clients.
+type IntervalOption interface {
+	applyInterval(*intervalConfig)
+}
+
+type intervalConfig struct {
+	offsets bool
+	exact   bool
+	maxSpan time.Duration
+}
+
+func newIntervalConfig(opts []IntervalOption) *intervalConfig {
+	c := new(intervalConfig)
+	for _, opt := range opts {
+		opt.applyInterval(c)
+	}
+	return c
+}
+
+type intervalOptionFunc func(*intervalConfig)
+
+func (f intervalOptionFunc) applyInterval(c *intervalConfig) { f(c) }
+
+// RequireOffset rejects times without a UTC offset or "Z", including dates
+// alone, with ErrBadFormat, rather than taking them as UTC. The end of an
+// interval in the concise form shares the offset of its start.
+func RequireOffset() IntervalOption {
+	return intervalOptionFunc(func(c *intervalConfig) { c.offsets = true })
+}
+
+// ExactPeriod rejects durations with year or month elements, whose length
+// depends on the date they are added to, with ErrNoYear or ErrNoMonth, so that
+// computed ends do not depend on calendar arithmetic.
+func ExactPeriod() IntervalOption {
+	return intervalOptionFunc(func(c *intervalConfig) { c.exact = true })
+}
+
+// MaxSpan rejects intervals longer than d, and those with an open end, with
+// ErrRange. For repeating intervals it bounds a single repetition.
+func MaxSpan(d time.Duration) IntervalOption {
+	return intervalOptionFunc(func(c *intervalConfig) { c.maxSpan = d })
+}
+
+// A RecurrenceOption limits the repetitions generated by
+// RepeatingInterval.Occurrences and the parts made by Interval.SplitBy, so
+// that schedules received from untrusted clients, such as "R999999999/PT1S",
+// cannot run without end or exhaust memory.
+type RecurrenceOption interface {
+	applyRecurrence(*recurrenceConfig)
+}
+
+type recurrenceConfig struct {
+	count   int
+	horizon time.Duration
+}
+
+func newRecurrenceConfig(opts []RecurrenceOption) *recurrenceConfig {
+	c := new(recurrenceConfig)
+	for _, opt := range opts {
+		opt.applyRecurrence(c)
+	}
+	return c
+}
+
+// allows reports whether the nth repetition, counting from 0, starting at t
+// is within the limits for a schedule starting at first.
+func (c *recurrenceConfig) allows(n int, first, t time.Time) bool {
+	return (c.count <= 0 || n < c.count) && (c.horizon <= 0 || t.Sub(first) <= c.horizon)
+}
+
+type recurrenceOptionFunc func(*recurrenceConfig)
+
+func (f recurrenceOptionFunc) applyRecurrence(c *recurrenceConfig) { f(c) }
+
+// MaxOccurrences generates at most n repetitions or parts.
+func MaxOccurrences(n int) RecurrenceOption {
+	return recurrenceOptionFunc(func(c *recurrenceConfig) { c.count = n })
+}
+
+// MaxHorizon generates no repetitions or parts starting more than d after the
+// first.
+func MaxHorizon(d time.Duration) RecurrenceOption {
+	return recurrenceOptionFunc(func(c *recurrenceConfig) { c.horizon = d })
+}
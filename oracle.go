@@ -0,0 +1,235 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatOracleDaySecond returns d as an Oracle INTERVAL DAY TO SECOND literal,
+// such as "INTERVAL '4 05:12:10.222' DAY TO SECOND". The leading and
+// fractional precisions are written only when the value needs more than
+// Oracle's defaults of 2 and 6 digits (e.g. "DAY(3)" for 100 days or more).
+func FormatOracleDaySecond(d time.Duration) string {
+	b := append([]byte(nil), "INTERVAL '"...)
+
+	n := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		n = uint64(-d)
+	}
+
+	days := n / uint64(dayTime)
+	n %= uint64(dayTime)
+	b = strconv.AppendUint(b, days, 10)
+	b = append(b, ' ')
+	b = appendPadded(b, int64(n/uint64(time.Hour)), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(n/uint64(time.Minute)%60), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(n/uint64(time.Second)%60), 2)
+
+	digits := 0
+	if frac := int64(n % uint64(time.Second)); frac != 0 {
+		digits = 9
+		for frac%10 == 0 {
+			frac /= 10
+			digits--
+		}
+		b = append(b, '.')
+		b = appendPadded(b, frac, digits)
+	}
+
+	b = append(b, "' DAY"...)
+	if p := len(strconv.FormatUint(days, 10)); p > 2 {
+		b = append(b, '(')
+		b = strconv.AppendInt(b, int64(p), 10)
+		b = append(b, ')')
+	}
+	b = append(b, " TO SECOND"...)
+	if digits > 6 {
+		b = append(b, "(9)"...)
+	}
+	return string(b)
+}
+
+// ParseOracleDaySecond parses an Oracle INTERVAL DAY TO SECOND value, either
+// as a literal such as "INTERVAL '4 05:12:10.222' DAY(3) TO SECOND" or as the
+// bare value "+000000004 05:12:10.222000" returned by Oracle drivers. Hours
+// must be less than 24 and minutes and seconds less than 60, otherwise
+// ErrRange is returned.
+func ParseOracleDaySecond(s string) (time.Duration, error) {
+	v, err := intervalLiteral(s, "DAY", "SECOND")
+	if err != nil {
+		return 0, err
+	}
+
+	neg, v := intervalSign(v)
+	days, clock, ok := strings.Cut(v, " ")
+	if !ok || days == "" || skipDigits(days, 0) != len(days) || strings.Count(clock, ":") != 2 {
+		return 0, ErrBadFormat
+	}
+
+	n, err := strconv.ParseInt(days, 10, 64)
+	if err != nil || n > int64(math.MaxInt64/dayTime) {
+		return 0, ErrRange
+	}
+	if clock[0] < '0' || clock[0] > '9' {
+		return 0, ErrBadFormat
+	}
+	t, err := ParseClock(clock)
+	if err != nil {
+		return 0, err
+	}
+	if t >= dayTime {
+		return 0, ErrRange
+	}
+
+	d := time.Duration(n) * dayTime
+	if d += t; d < 0 {
+		return 0, ErrRange
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// FormatOracleYearMonth returns the years and months of c as an Oracle
+// INTERVAL YEAR TO MONTH literal, such as "INTERVAL '123-2' YEAR(3) TO MONTH".
+// Months of 12 or more are carried into years. The years and months must be
+// whole numbers and the other fields zero, otherwise ErrBadFormat is returned.
+func FormatOracleYearMonth(c Components) (string, error) {
+	for u := Year; u < numUnits; u++ {
+		v := *c.field(u)
+		if (u != Year && u != Month && v != 0) || v != math.Trunc(v) || math.IsInf(v, 0) {
+			return "", ErrBadFormat
+		}
+	}
+
+	months := c.Years*12 + c.Months
+	if math.Abs(months) >= 1<<53 {
+		return "", ErrRange
+	}
+
+	b := append([]byte(nil), "INTERVAL '"...)
+	if months < 0 {
+		b = append(b, '-')
+		months = -months
+	}
+
+	years := strconv.FormatInt(int64(months)/12, 10)
+	b = append(b, years...)
+	b = append(b, '-')
+	b = strconv.AppendInt(b, int64(months)%12, 10)
+	b = append(b, "' YEAR"...)
+	if len(years) > 2 {
+		b = append(b, '(')
+		b = strconv.AppendInt(b, int64(len(years)), 10)
+		b = append(b, ')')
+	}
+	b = append(b, " TO MONTH"...)
+	return string(b), nil
+}
+
+// ParseOracleYearMonth parses an Oracle INTERVAL YEAR TO MONTH value, either as
+// a literal such as "INTERVAL '123-2' YEAR(3) TO MONTH" or as the bare value
+// "+000000123-02" returned by Oracle drivers, into years and months. Months
+// must be less than 12, otherwise ErrRange is returned.
+func ParseOracleYearMonth(s string) (Components, error) {
+	v, err := intervalLiteral(s, "YEAR", "MONTH")
+	if err != nil {
+		return Components{}, err
+	}
+
+	neg, v := intervalSign(v)
+	years, months, ok := strings.Cut(v, "-")
+	if !ok || years == "" || months == "" || skipDigits(years, 0) != len(years) || skipDigits(months, 0) != len(months) {
+		return Components{}, ErrBadFormat
+	}
+
+	y, err := strconv.ParseInt(years, 10, 64)
+	if err != nil {
+		return Components{}, ErrRange
+	}
+	m, err := strconv.ParseInt(months, 10, 64)
+	if err != nil || m >= 12 {
+		return Components{}, ErrRange
+	}
+
+	c := Components{Years: float64(y), Months: float64(m)}
+	if neg {
+		c = Components{Years: -c.Years, Months: -c.Months}
+	}
+	return c, nil
+}
+
+// intervalLiteral returns the quoted value of s if it is an SQL interval
+// literal with the qualifier "<lead> TO <trail>", in which the fields may
+// carry precisions such as "DAY(3)". Otherwise s is returned as a bare value
+// with surrounding white space removed.
+func intervalLiteral(s, lead, trail string) (string, error) {
+	v, qual, ok, err := splitInterval(s)
+	switch {
+	case err != nil:
+		return "", err
+	case !ok:
+		return strings.TrimSpace(s), nil
+	case len(qual) != 3 || !strings.EqualFold(qual[0], lead) ||
+		!strings.EqualFold(qual[1], "TO") || !strings.EqualFold(qual[2], trail):
+		return "", ErrBadFormat
+	}
+	return v, nil
+}
+
+// splitInterval splits an SQL interval literal such as
+// "INTERVAL '4 05:12' DAY(3) TO MINUTE" into its quoted value and the words of
+// its qualifier, with any precisions removed. ok is false if s does not start
+// with INTERVAL.
+func splitInterval(s string) (v string, qual []string, ok bool, err error) {
+	s = strings.TrimSpace(s)
+	if len(s) < len("INTERVAL") || !strings.EqualFold(s[:len("INTERVAL")], "INTERVAL") {
+		return "", nil, false, nil
+	}
+
+	rest := strings.TrimSpace(s[len("INTERVAL"):])
+	if !strings.HasPrefix(rest, "'") {
+		return "", nil, true, ErrBadFormat
+	}
+	v, q, found := strings.Cut(rest[1:], "'")
+	if !found {
+		return "", nil, true, ErrBadFormat
+	}
+	return v, strings.Fields(stripPrecisions(q)), true, nil
+}
+
+// stripPrecisions removes parenthesized precisions such as "(3)" from an
+// interval qualifier.
+func stripPrecisions(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '(')
+		if i == -1 {
+			break
+		}
+		j := strings.IndexByte(s[i:], ')')
+		if j == -1 {
+			break
+		}
+		b.WriteString(s[:i])
+		b.WriteByte(' ')
+		s = s[i+j+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// intervalSign removes a leading "+" or "-" from an interval value, reporting
+// whether it was negative.
+func intervalSign(s string) (bool, string) {
+	if strings.HasPrefix(s, "-") {
+		return true, s[1:]
+	}
+	return false, strings.TrimPrefix(s, "+")
+}
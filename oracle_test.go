@@ -0,0 +1,132 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOracleDaySecond(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{4*dayTime + 5*time.Hour + 12*time.Minute + 10222*time.Millisecond, "INTERVAL '4 05:12:10.222' DAY TO SECOND"},
+		{0, "INTERVAL '0 00:00:00' DAY TO SECOND"},
+		{90 * time.Minute, "INTERVAL '0 01:30:00' DAY TO SECOND"},
+		{123 * dayTime, "INTERVAL '123 00:00:00' DAY(3) TO SECOND"},
+		{time.Nanosecond, "INTERVAL '0 00:00:00.000000001' DAY TO SECOND(9)"},
+		{-(dayTime + time.Second), "INTERVAL '-1 00:00:01' DAY TO SECOND"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, FormatOracleDaySecond(vec.in), vec.in)
+
+		d, err := ParseOracleDaySecond(vec.out)
+		assert.NoError(t, err, vec.out)
+		assert.Equal(t, vec.in, d, vec.out)
+	}
+}
+
+func TestParseOracleDaySecond(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"+000000004 05:12:10.222000", 4*dayTime + 5*time.Hour + 12*time.Minute + 10222*time.Millisecond},
+		{"-000000001 00:00:00.000000", -dayTime},
+		{"interval '2 12:00:00' day(9) to second(0)", 60 * time.Hour},
+		{"INTERVAL '2 12:00:00' DAY (3) TO SECOND", 60 * time.Hour},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseOracleDaySecond(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"4", ErrBadFormat},
+		{"4 05:12", ErrBadFormat},
+		{"4 -05:12:00", ErrBadFormat},
+		{"INTERVAL '4 05:12:10' DAY TO MINUTE", ErrBadFormat},
+		{"INTERVAL '4 05:12:10 DAY TO SECOND", ErrBadFormat},
+		{"INTERVAL 4 05:12:10 DAY TO SECOND", ErrBadFormat},
+		{"4 24:00:00", ErrRange},
+		{"4 05:60:00", ErrRange},
+		{"200000 00:00:00", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseOracleDaySecond(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestOracleYearMonth(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out string
+	}{
+		{Components{Years: 123, Months: 2}, "INTERVAL '123-2' YEAR(3) TO MONTH"},
+		{Components{Years: 1}, "INTERVAL '1-0' YEAR TO MONTH"},
+		{Components{Months: 14}, "INTERVAL '1-2' YEAR TO MONTH"},
+		{Components{}, "INTERVAL '0-0' YEAR TO MONTH"},
+		{Components{Years: -1, Months: -6}, "INTERVAL '-1-6' YEAR TO MONTH"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatOracleYearMonth(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	for _, in := range []Components{{Days: 1}, {Years: 1.5}, {Months: 1, Hours: 1}} {
+		_, err := FormatOracleYearMonth(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+
+	parsed := []struct {
+		in  string
+		out Components
+	}{
+		{"INTERVAL '123-2' YEAR(3) TO MONTH", Components{Years: 123, Months: 2}},
+		{"+000000123-02", Components{Years: 123, Months: 2}},
+		{"-000000001-06", Components{Years: -1, Months: -6}},
+		{"1-0", Components{Years: 1}},
+	}
+
+	for _, vec := range parsed {
+		c, err := ParseOracleYearMonth(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, c, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"1", ErrBadFormat},
+		{"1-", ErrBadFormat},
+		{"1--2", ErrBadFormat},
+		{"INTERVAL '1-2' DAY TO SECOND", ErrBadFormat},
+		{"1-12", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseOracleYearMonth(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
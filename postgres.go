@@ -0,0 +1,164 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParsePostgres parses an interval in the default output style of PostgreSQL
+// (IntervalStyle "postgres"), such as "1 year 2 mons 3 days 04:05:06.5" or
+// "-1 days +02:00:00". Years, months and days are returned as written; the
+// time of day is split into hours, minutes and seconds. Use FormatComponents
+// to convert the result to ISO8601.
+func ParsePostgres(s string) (Components, error) {
+	var c Components
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Components{}, ErrBadFormat
+	}
+
+	last := Unit(-1)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Contains(f, ":") {
+			if i != len(fields)-1 {
+				return Components{}, ErrBadFormat
+			}
+			if err := parsePostgresTime(f, &c); err != nil {
+				return Components{}, err
+			}
+			break
+		}
+
+		if i+1 == len(fields) {
+			return Components{}, ErrBadFormat
+		}
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return Components{}, ErrBadFormat
+		}
+
+		var u Unit
+		switch fields[i+1] {
+		case "year", "years":
+			u = Year
+		case "mon", "mons":
+			u = Month
+		case "day", "days":
+			u = Day
+		default:
+			return Components{}, ErrBadFormat
+		}
+		if u <= last {
+			return Components{}, ErrBadFormat
+		}
+		last = u
+
+		*c.field(u) = float64(n)
+		i++
+	}
+	return c, nil
+}
+
+// parsePostgresTime parses the "[+-]HH:MM:SS[.ffffff]" field of an interval
+// into c.
+func parsePostgresTime(s string, c *Components) error {
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	if strings.Count(s, ":") != 2 || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return ErrBadFormat
+	}
+
+	d, err := ParseClock(s)
+	if err != nil {
+		return err
+	}
+
+	sc := Split(d)
+	c.Hours = sc.Days*24 + sc.Hours
+	c.Minutes = sc.Minutes
+	c.Seconds = sc.Seconds
+	if neg {
+		c.Hours, c.Minutes, c.Seconds = -c.Hours, -c.Minutes, -c.Seconds
+	}
+	return nil
+}
+
+// FormatPostgres returns c in the default output style of PostgreSQL, such
+// as "1 year 2 mons 3 days 04:05:06.5", which PostgreSQL also accepts as
+// input. Years, months, weeks and days must be whole numbers, otherwise
+// ErrBadFormat is returned; weeks are added to days. Hours, minutes and seconds
+// are combined into a single time of day with at most microsecond precision.
+// Fields may have different signs.
+func FormatPostgres(c Components) (string, error) {
+	var b []byte
+	var neg bool
+
+	days := c.Days + 7*c.Weeks
+	for _, f := range [...]struct {
+		v          float64
+		one, other string
+	}{{c.Years, "year", "years"}, {c.Months, "mon", "mons"}, {days, "day", "days"}} {
+		switch {
+		case math.IsNaN(f.v) || math.IsInf(f.v, 0) || f.v != math.Trunc(f.v):
+			return "", ErrBadFormat
+		case math.Abs(f.v) >= 1<<53:
+			return "", ErrRange
+		case f.v == 0:
+			continue
+		}
+
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendInt(b, int64(f.v), 10)
+		b = append(b, ' ')
+		if f.v == 1 {
+			b = append(b, f.one...)
+		} else {
+			b = append(b, f.other...)
+		}
+		neg = neg || f.v < 0
+	}
+
+	secs := c.Hours*3600 + c.Minutes*60 + c.Seconds
+	if math.IsNaN(secs) || math.IsInf(secs, 0) {
+		return "", ErrBadFormat
+	}
+	us := math.Round(math.Abs(secs) * 1e6)
+	if us >= 1<<63 {
+		return "", ErrRange
+	}
+
+	if us != 0 || len(b) == 0 {
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		switch {
+		case secs < 0:
+			b = append(b, '-')
+		case neg:
+			b = append(b, '+')
+		}
+
+		n := int64(us)
+		b = appendPadded(b, n/3600e6, 2)
+		b = append(b, ':')
+		b = appendPadded(b, n/60e6%60, 2)
+		b = append(b, ':')
+		b = appendPadded(b, n/1e6%60, 2)
+		if frac := n % 1e6; frac != 0 {
+			digits := 6
+			for frac%10 == 0 {
+				frac /= 10
+				digits--
+			}
+			b = append(b, '.')
+			b = appendPadded(b, frac, digits)
+		}
+	}
+	return string(b), nil
+}
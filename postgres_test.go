@@ -0,0 +1,99 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePostgres(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Components
+	}{
+		{"1 year 2 days 03:04:05", Components{Years: 1, Days: 2, Hours: 3, Minutes: 4, Seconds: 5}},
+		{"1 day 02:03:04.5", Components{Days: 1, Hours: 2, Minutes: 3, Seconds: 4.5}},
+		{"1 year 2 mons", Components{Years: 1, Months: 2}},
+		{"3 mons", Components{Months: 3}},
+		{"00:00:00", Components{}},
+		{"36:00:00", Components{Hours: 36}},
+		{"-1 days +02:00:00", Components{Days: -1, Hours: 2}},
+		{"1 day -02:30:00", Components{Days: 1, Hours: -2, Minutes: -30}},
+		{"-00:00:00.000001", Components{Seconds: -0.000001}},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParsePostgres(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, c, vec.in)
+	}
+
+	for _, in := range []string{"", "1", "1 hour", "1 day 2 years", "1 day 1 day", "01:00:00 1 day", "1.5 days", "--01:00:00", "01:00", "P1D"} {
+		_, err := ParsePostgres(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+}
+
+func TestFormatPostgres(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out string
+	}{
+		{Components{Years: 1, Days: 2, Hours: 3, Minutes: 4, Seconds: 5}, "1 year 2 days 03:04:05"},
+		{Components{Days: 1, Hours: 2, Minutes: 3, Seconds: 4.5}, "1 day 02:03:04.5"},
+		{Components{Years: 2, Months: 1}, "2 years 1 mon"},
+		{Components{Weeks: 1, Days: 1}, "8 days"},
+		{Components{Minutes: 90}, "01:30:00"},
+		{Components{Hours: 36}, "36:00:00"},
+		{Components{Seconds: 0.000001}, "00:00:00.000001"},
+		{Components{Seconds: 0.0000001}, "00:00:00"},
+		{Components{}, "00:00:00"},
+		{Components{Days: -1, Hours: 2}, "-1 days +02:00:00"},
+		{Components{Days: 1, Hours: -2}, "1 day -02:00:00"},
+		{Components{Months: -3}, "-3 mons"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatPostgres(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	for _, in := range []Components{{Days: 1.5}, {Years: math.NaN()}, {Seconds: math.Inf(1)}} {
+		_, err := FormatPostgres(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+}
+
+func TestPostgresISO(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		pg  string
+		iso string
+	}{
+		{"1 year 2 days 03:04:05", "P1Y2DT3H4M5S"},
+		{"1 day 02:03:04.5", "P1DT2H3M4.5S"},
+		{"1 year 2 mons", "P1Y2M"},
+		{"36:00:00", "PT36H"},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParsePostgres(vec.pg)
+		assert.NoError(t, err, vec.pg)
+		s, err := FormatComponents(c)
+		assert.NoError(t, err, vec.pg)
+		assert.Equal(t, vec.iso, s)
+
+		c, err = ParseComponents(vec.iso)
+		assert.NoError(t, err, vec.iso)
+		s, err = FormatPostgres(c)
+		assert.NoError(t, err, vec.iso)
+		assert.Equal(t, vec.pg, s)
+	}
+}
@@ -0,0 +1,208 @@
+package duration
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Profile is a named set of rules for the ISO8601 durations exchanged with
+// one integration, so that the rules can be chosen at run time by name (e.g.
+// from configuration) with LookupProfile.
+type Profile struct {
+	// Name identifies the profile, e.g. "xsd".
+	Name string
+
+	// ParseOptions are passed to ParseWithOptions by Parse.
+	ParseOptions []ParseOption
+
+	// FormatOptions are passed to FormatWithOptions by Format.
+	FormatOptions []FormatOption
+
+	// Validate, if not nil, checks a string before Parse parses it, for rules
+	// that ParseOptions cannot express. The string has been trimmed as
+	// ParseOptions direct.
+	Validate func(s string) error
+}
+
+// Parse parses s as ParseWithOptions does with the ParseOptions of p, after
+// checking it with Validate.
+func (p Profile) Parse(s string) (time.Duration, error) {
+	if p.Validate != nil {
+		if err := p.Validate(newParseConfig(p.ParseOptions).trim(s)); err != nil {
+			return 0, err
+		}
+	}
+	return ParseWithOptions(s, p.ParseOptions...)
+}
+
+// Normalize parses s with Parse and returns it as written by Format, so that
+// values received from an integration are checked against its rules and
+// stored in one form (e.g. "PT1H0M5S" becomes "PT1H5S" for "youtube").
+func (p Profile) Normalize(s string) (string, error) {
+	d, err := p.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return p.Format(d)
+}
+
+// Format returns d as FormatWithOptions does with the FormatOptions of p.
+func (p Profile) Format(d time.Duration) (string, error) {
+	return FormatWithOptions(d, p.FormatOptions...)
+}
+
+var profiles struct {
+	sync.RWMutex
+	m map[string]Profile
+}
+
+// RegisterProfile makes p available by its name to LookupProfile. It panics
+// if p has no name or a profile with the same name is already registered.
+func RegisterProfile(p Profile) {
+	profiles.Lock()
+	defer profiles.Unlock()
+
+	if p.Name == "" {
+		panic("duration: RegisterProfile called with empty name")
+	}
+	if _, dup := profiles.m[p.Name]; dup {
+		panic("duration: RegisterProfile called twice for profile " + p.Name)
+	}
+	if profiles.m == nil {
+		profiles.m = make(map[string]Profile)
+	}
+	profiles.m[p.Name] = p
+}
+
+// LookupProfile returns the profile registered with the given name, which is
+// case-sensitive. The built-in profiles are:
+//
+//   - "rfc3339": the grammar of RFC 3339 Appendix A, also used by the
+//     "duration" format of JSON Schema; see IsJSONSchemaDuration.
+//   - "xsd": the xs:duration type of XML Schema, with a leading "-" for
+//     negative durations and fractions in seconds only.
+//   - "ical": the DURATION values of iCalendar (RFC 5545), with an optional
+//     sign, whole days, weeks, hours, minutes and seconds only.
+//   - "youtube": the durations returned by the YouTube Data API, such as
+//     "PT15M33S", "PT1H2M3S", "P1DT2H" or "P0D" for live broadcasts: whole
+//     days, hours, minutes and seconds only, in order and without a sign.
+//     Years fail with ErrNoYear and months with ErrNoMonth.
+//   - "dash": the duration attributes of MPEG-DASH manifests, as xs:duration
+//     without a sign or calendar elements, written with hours as the largest
+//     unit and millisecond precision (e.g. "PT1H2M3.5S"). See also CheckDASH.
+//   - "azure": the timeGrain values of Azure Monitor, PT1M to P1D. Other
+//     durations fail with ErrRange; see SnapAzureTimeGrain.
+func LookupProfile(name string) (Profile, bool) {
+	profiles.RLock()
+	defer profiles.RUnlock()
+
+	p, ok := profiles.m[name]
+	return p, ok
+}
+
+// ProfileNames returns the names of the registered profiles, sorted.
+func ProfileNames() []string {
+	profiles.RLock()
+	defer profiles.RUnlock()
+
+	names := make([]string, 0, len(profiles.m))
+	for name := range profiles.m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// icalProfile is the "ical" profile, also used by ParseTrigger.
+var icalProfile = Profile{
+	Name:          "ical",
+	ParseOptions:  []ParseOption{AllowNegative(), parseOptionFunc(func(c *parseConfig) { c.signed = true })},
+	FormatOptions: []FormatOption{AllowNegative(), UseWeeks(), weeksOrDays, NoGaps(), Rounding(RoundHalfEven), ZeroAs(ZeroSeconds)},
+	Validate: func(s string) error {
+		if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+			s = s[1:]
+		}
+		return check(rfc3339Duration(s, "D"))
+	},
+}
+
+// weeksOrDays caps the largest unit at weeks, which UseWeeks writes but
+// LargestUnit cannot select, so that years are written as days.
+var weeksOrDays = formatOptionFunc(func(c *formatConfig) { c.largest = Week })
+
+func init() {
+	for _, p := range [...]Profile{
+		{
+			Name:          "rfc3339",
+			FormatOptions: []FormatOption{LargestUnit(Day), NoGaps(), Rounding(RoundHalfEven), ZeroAs(ZeroSeconds)},
+			Validate: func(s string) error {
+				return check(rfc3339Duration(s, "YMD"))
+			},
+		},
+		{
+			Name:          "xsd",
+			ParseOptions:  []ParseOption{AllowNegative(), DecimalSeparator(SeparatorDot)},
+			FormatOptions: []FormatOption{AllowNegative(), TrimZeros(), ZeroAs(ZeroSeconds)},
+			Validate:      validXSD,
+		},
+		icalProfile,
+		{
+			Name:          "youtube",
+			FormatOptions: []FormatOption{LargestUnit(Day), Rounding(RoundHalfEven), ZeroAs(ZeroDays)},
+			Validate: func(s string) error {
+				s, ok := strings.CutPrefix(s, "P")
+				date, tm, hasTime := strings.Cut(s, "T")
+				switch {
+				case strings.Contains(date, "Y"):
+					return ErrNoYear
+				case strings.Contains(date, "M"):
+					return ErrNoMonth
+				}
+				return check(ok && s != "" && integerElements(date, "D", true) && (!hasTime || tm != "" && integerElements(tm, "HMS", true)))
+			},
+		},
+		{
+			Name:          "dash",
+			ParseOptions:  []ParseOption{RejectYears(), DecimalSeparator(SeparatorDot)},
+			FormatOptions: []FormatOption{LargestUnit(Hour), Precision(3), TrimZeros(), ZeroAs(ZeroSeconds)},
+			Validate:      validXSD,
+		},
+		{
+			Name:          "azure",
+			FormatOptions: []FormatOption{LargestUnit(Day)},
+			Validate: func(s string) error {
+				d, err := Parse(s)
+				if err != nil {
+					return err
+				}
+				if !slices.Contains(azureGrains, d) {
+					return ErrRange
+				}
+				return nil
+			},
+		},
+	} {
+		RegisterProfile(p)
+	}
+}
+
+// validXSD checks the rules of xs:duration that ParseWithOptions does not
+// enforce: no weeks, no "T" without time elements after it, and a fraction in
+// the seconds element only.
+func validXSD(s string) error {
+	if strings.Contains(s, "W") || strings.HasSuffix(s, "T") {
+		return ErrBadFormat
+	}
+	i := strings.IndexByte(s, '.')
+	return check(i == -1 || skipDigits(s, i+1) == len(s)-1 && strings.HasSuffix(s, "S"))
+}
+
+// check returns ErrBadFormat unless ok.
+func check(ok bool) error {
+	if !ok {
+		return ErrBadFormat
+	}
+	return nil
+}
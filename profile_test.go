@@ -0,0 +1,184 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileParse(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		profile string
+		in      string
+		out     time.Duration
+		err     error
+	}{
+		{"rfc3339", "P1DT2H", 26 * time.Hour, nil},
+		{"rfc3339", "PT1H0M1S", time.Hour + time.Second, nil},
+		{"rfc3339", "P1W", weekTime, nil},
+		{"rfc3339", "PT1H1S", 0, ErrBadFormat},
+		{"rfc3339", "PT1.5S", 0, ErrBadFormat},
+		{"rfc3339", "-PT1S", 0, ErrBadFormat},
+		{"rfc3339", "P1M", 0, ErrNoMonth},
+
+		{"xsd", "-P1DT2H", -26 * time.Hour, nil},
+		{"xsd", "PT1.5S", 1500 * time.Millisecond, nil},
+		{"xsd", " PT1H ", time.Hour, nil},
+		{"xsd", "PT1.5H", 0, ErrBadFormat},
+		{"xsd", "PT1,5S", 0, ErrBadFormat},
+		{"xsd", "P1W", 0, ErrBadFormat},
+		{"xsd", "P1DT", 0, ErrBadFormat},
+		{"xsd", "-P1DT", 0, ErrBadFormat},
+
+		{"ical", "-PT15M", -15 * time.Minute, nil},
+		{"ical", "+P1W", weekTime, nil},
+		{"ical", "P1DT12H", 36 * time.Hour, nil},
+		{"ical", "P1Y", 0, ErrBadFormat},
+		{"ical", "PT1H1S", 0, ErrBadFormat},
+		{"ical", "+-PT1H", 0, ErrBadFormat},
+		{"ical", "P-1D", 0, ErrBadFormat},
+
+		{"youtube", "PT15M33S", 15*time.Minute + 33*time.Second, nil},
+		{"youtube", "P1DT2H3M4S", 26*time.Hour + 3*time.Minute + 4*time.Second, nil},
+		{"youtube", "P0D", 0, nil},
+		{"youtube", "PT1.5S", 0, ErrBadFormat},
+		{"youtube", "P1W", 0, ErrBadFormat},
+		{"youtube", "PT1H", time.Hour, nil},
+		{"youtube", "PT1H5S", time.Hour + 5*time.Second, nil},
+		{"youtube", "PT1H0M5S", time.Hour + 5*time.Second, nil},
+		{"youtube", "P2D", 2 * dayTime, nil},
+		{"youtube", "P1Y", 0, ErrNoYear},
+		{"youtube", "P1M", 0, ErrNoMonth},
+		{"youtube", "P", 0, ErrBadFormat},
+		{"youtube", "PT", 0, ErrBadFormat},
+		{"youtube", "P1DT", 0, ErrBadFormat},
+		{"youtube", "-PT1S", 0, ErrBadFormat},
+		{"youtube", "PT5S1M", 0, ErrBadFormat},
+		{"youtube", "PT1,5S", 0, ErrBadFormat},
+		{"youtube", "PT1H1H", 0, ErrBadFormat},
+		{"youtube", "pt1s", 0, ErrBadFormat},
+
+		{"dash", "PT0H9M56.46S", 9*time.Minute + 56460*time.Millisecond, nil},
+		{"dash", "PT634.2S", 634200 * time.Millisecond, nil},
+		{"dash", "P1Y", 0, ErrNoYear},
+		{"dash", "P1DT", 0, ErrBadFormat},
+		{"dash", "P1W", 0, ErrBadFormat},
+		{"dash", "PT1.5H", 0, ErrBadFormat},
+		{"dash", "-PT1S", 0, ErrBadFormat},
+
+		{"azure", "PT5M", 5 * time.Minute, nil},
+		{"azure", "P1D", dayTime, nil},
+		{"azure", "PT2M", 0, ErrRange},
+		{"azure", "P1W", 0, ErrRange},
+		{"azure", "5m", 0, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		p, ok := LookupProfile(vec.profile)
+		require.True(t, ok, vec.profile)
+
+		d, err := p.Parse(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%s %s", vec.profile, vec.in)
+		assert.Equal(t, vec.out, d, "%s %s", vec.profile, vec.in)
+	}
+}
+
+func TestProfileFormat(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		profile string
+		in      time.Duration
+		out     string
+		err     error
+	}{
+		{"rfc3339", time.Hour + time.Second, "PT1H0M1S", nil},
+		{"rfc3339", yearTime + 2*dayTime, "P367D", nil},
+		{"rfc3339", 1500 * time.Millisecond, "PT2S", nil},
+		{"rfc3339", 0, "PT0S", nil},
+		{"xsd", -1500 * time.Millisecond, "-PT1.5S", nil},
+		{"xsd", 0, "PT0S", nil},
+		{"ical", -15 * time.Minute, "-PT15M", nil},
+		{"ical", 2 * weekTime, "P2W", nil},
+		{"ical", yearTime, "P365D", nil},
+		{"ical", time.Hour + time.Second, "PT1H0M1S", nil},
+		{"youtube", 15*time.Minute + 33*time.Second, "PT15M33S", nil},
+		{"youtube", 0, "P0D", nil},
+		{"dash", 9*time.Minute + 56460*time.Millisecond, "PT9M56.46S", nil},
+		{"dash", 26 * time.Hour, "PT26H", nil},
+		{"azure", dayTime, "P1D", nil},
+		{"youtube", -time.Second, "", ErrNoNegative},
+	}
+
+	for _, vec := range vecs {
+		p, ok := LookupProfile(vec.profile)
+		require.True(t, ok, vec.profile)
+
+		s, err := p.Format(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%s %v", vec.profile, vec.in)
+		assert.Equal(t, vec.out, s, "%s %v", vec.profile, vec.in)
+
+		if err == nil && vec.in%time.Second == 0 {
+			d, err := p.Parse(s)
+			assert.NoError(t, err, "%s %s", vec.profile, s)
+			assert.Equal(t, vec.in, d, "%s %s", vec.profile, s)
+		}
+	}
+}
+
+func TestProfileNormalize(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		profile string
+		in      string
+		out     string
+		err     error
+	}{
+		{"youtube", "PT1H0M5S", "PT1H5S", nil},
+		{"youtube", "PT90M", "PT1H30M", nil},
+		{"youtube", "PT36H", "P1DT12H", nil},
+		{"youtube", "P0D", "P0D", nil},
+		{"youtube", "PT0S", "P0D", nil},
+		{"youtube", "PT1.5S", "", ErrBadFormat},
+		{"xsd", "PT1.500S", "PT1.5S", nil},
+		{"xsd", "P1DT", "", ErrBadFormat},
+		{"azure", "PT60M", "PT1H", nil},
+	}
+
+	for _, vec := range vecs {
+		p, ok := LookupProfile(vec.profile)
+		require.True(t, ok, vec.profile)
+
+		s, err := p.Normalize(vec.in)
+		assert.ErrorIs(t, err, vec.err, "%s %s", vec.profile, vec.in)
+		assert.Equal(t, vec.out, s, "%s %s", vec.profile, vec.in)
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	t.Parallel()
+
+	// Registration is global, so guard against -count
+	if _, ok := LookupProfile("test-minutes"); !ok {
+		RegisterProfile(Profile{Name: "test-minutes", FormatOptions: []FormatOption{LargestUnit(Minute)}})
+	}
+	p, ok := LookupProfile("test-minutes")
+	require.True(t, ok)
+	s, err := p.Format(2 * time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "PT120M", s)
+
+	assert.Contains(t, ProfileNames(), "test-minutes")
+	assert.Subset(t, ProfileNames(), []string{"azure", "dash", "ical", "rfc3339", "xsd", "youtube"})
+
+	_, ok = LookupProfile("XSD")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { RegisterProfile(Profile{Name: "xsd"}) })
+	assert.Panics(t, func() { RegisterProfile(Profile{}) })
+}
@@ -0,0 +1,139 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// labelUnit is a unit of the compact duration syntaxes of Prometheus and
+// InfluxDB, such as the "h" of "1h30m".
+type labelUnit struct {
+	label string
+	t     time.Duration
+	exact bool
+}
+
+// promUnits are the units of Prometheus durations, largest first. Years and
+// weeks are only written when they divide the duration exactly.
+var promUnits = []labelUnit{
+	{"y", yearTime, true},
+	{"w", weekTime, true},
+	{"d", dayTime, false},
+	{"h", time.Hour, false},
+	{"m", time.Minute, false},
+	{"s", time.Second, false},
+	{"ms", time.Millisecond, false},
+}
+
+// FromPrometheus converts a duration in the syntax used by Prometheus
+// configuration and queries (e.g. "1h30m", "2d", "1w" or "1y", with a year of
+// 365 days) to ISO8601, as written by FormatWithOptions with opts. As in
+// Prometheus, units must appear at most once and largest first, and values
+// must be whole numbers; other strings fail with ErrBadFormat.
+func FromPrometheus(s string, opts ...FormatOption) (string, error) {
+	d, err := parsePrometheus(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// ToPrometheus converts an ISO8601 duration, as accepted by ParseWithOptions
+// with opts, to the syntax used by Prometheus (e.g. "1h30m" for "PT1H30M"),
+// in the form Prometheus itself writes. Years and weeks are used only when
+// they divide the duration exactly, so "P90D" becomes "90d" rather than
+// "12w6d". The duration is truncated to milliseconds. Negative durations fail
+// with ErrNoNegative.
+func ToPrometheus(s string, opts ...ParseOption) (string, error) {
+	d, err := ParseWithOptions(s, opts...)
+	if err != nil {
+		return "", err
+	}
+	if d < 0 {
+		return "", ErrNoNegative
+	}
+
+	return string(appendLabelUnits(nil, d.Truncate(time.Millisecond), promUnits)), nil
+}
+
+// parsePrometheus parses a duration in the syntax used by Prometheus.
+func parsePrometheus(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	return parseLabelUnits(s, promUnits)
+}
+
+// appendLabelUnits appends the non-negative duration d using units, writing
+// "0s" for zero. Units marked exact are skipped unless they divide the
+// remainder exactly, and the remainder below the last unit is dropped.
+func appendLabelUnits(b []byte, d time.Duration, units []labelUnit) []byte {
+	if d < units[len(units)-1].t {
+		return append(b, "0s"...)
+	}
+	for _, u := range units {
+		if u.t == 0 || d < u.t || (u.exact && d%u.t != 0) {
+			continue
+		}
+		b = strconv.AppendInt(b, int64(d/u.t), 10)
+		b = append(b, u.label...)
+		d %= u.t
+	}
+	return b
+}
+
+// parseLabelUnits parses a sequence of whole numbers, each followed by one of
+// units, which must appear at most once and in the order given. Consecutive
+// units of the same length are alternative labels for one unit. Units with no
+// length are months, which fail with ErrNoMonth.
+func parseLabelUnits(s string, units []labelUnit) (time.Duration, error) {
+	if s == "" {
+		return 0, ErrBadFormat
+	}
+
+	var d time.Duration
+	next := 0
+	for s != "" {
+		i := skipDigits(s, 0)
+		if i == 0 {
+			return 0, ErrBadFormat
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, ErrRange
+		}
+		s = s[i:]
+
+		j := next
+		for j < len(units) && !hasUnit(s, units[j].label) {
+			j++
+		}
+		if j == len(units) {
+			return 0, ErrBadFormat
+		}
+		s = s[len(units[j].label):]
+		t := units[j].t
+		for next = j + 1; next < len(units) && units[next].t == t; next++ {
+		}
+
+		if t == 0 {
+			return 0, ErrNoMonth
+		}
+		if n > int64((math.MaxInt64-d)/t) {
+			return 0, ErrRange
+		}
+		d += time.Duration(n) * t
+	}
+	return d, nil
+}
+
+// hasUnit reports whether s starts with the unit label and not with a longer
+// label, such as "ms" for "m".
+func hasUnit(s, label string) bool {
+	if !strings.HasPrefix(s, label) {
+		return false
+	}
+	return len(s) == len(label) || s[len(label)] < 'a' || s[len(label)] > 'z'
+}
@@ -0,0 +1,94 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromPrometheus(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []FormatOption
+		out  string
+	}{
+		{"1h30m", nil, "PT1H30M"},
+		{"2d", nil, "P2D"},
+		{"1w", nil, "P7D"},
+		{"1w", []FormatOption{UseWeeks()}, "P1W"},
+		{"1y", nil, "P1Y"},
+		{"1y2w3d4h5m6s7ms", nil, "P1Y17DT4H5M6.007S"},
+		{"90m", nil, "PT1H30M"},
+		{"500ms", nil, "PT0.500S"},
+		{"1m30s", nil, "PT1M30S"},
+		{"0", nil, "P0Y"},
+		{"0s", []FormatOption{ZeroAs(ZeroSeconds)}, "PT0S"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FromPrometheus(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"1", ErrBadFormat},
+		{"1.5h", ErrBadFormat},
+		{"-1h", ErrBadFormat},
+		{"30m1h", ErrBadFormat},
+		{"1h1h", ErrBadFormat},
+		{"1x", ErrBadFormat},
+		{"1ms1s", ErrBadFormat},
+		{"PT1H", ErrBadFormat},
+		{"300y", ErrRange},
+		{"99999999999999999999s", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := FromPrometheus(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestToPrometheus(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out string
+	}{
+		{"PT1H30M", "1h30m"},
+		{"P2D", "2d"},
+		{"P7D", "1w"},
+		{"P1W", "1w"},
+		{"P90D", "90d"},
+		{"P1Y", "1y"},
+		{"P1Y1D", "366d"},
+		{"PT6.007S", "6s7ms"},
+		{"PT0.0001S", "0s"},
+		{"P0Y", "0s"},
+	}
+
+	for _, vec := range vecs {
+		s, err := ToPrometheus(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		d, err := parsePrometheus(s)
+		assert.NoError(t, err, s)
+		d0, _ := Parse(vec.in)
+		assert.Equal(t, d0.Truncate(time.Millisecond), d, vec.in)
+	}
+
+	_, err := ToPrometheus("-PT1H", AllowNegative())
+	assert.ErrorIs(t, err, ErrNoNegative)
+	_, err = ToPrometheus("1h")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
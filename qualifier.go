@@ -0,0 +1,57 @@
+package duration
+
+import "time"
+
+// Qualifier is a set of ISO 8601-2 qualifiers attached to a duration value.
+type Qualifier uint8
+
+const (
+	// Approximate marks a value qualified with "~" (or "%").
+	Approximate Qualifier = 1 << iota
+
+	// Uncertain marks a value qualified with "?" (or "%").
+	Uncertain
+)
+
+// String returns the ISO 8601-2 suffix for q, or "" when q is empty.
+func (q Qualifier) String() string {
+	switch q {
+	case Approximate:
+		return "~"
+	case Uncertain:
+		return "?"
+	case Approximate | Uncertain:
+		return "%"
+	}
+	return ""
+}
+
+// ParseQualified parses a duration that may end with one of the ISO 8601-2
+// qualifiers "~" (approximate), "?" (uncertain) or "%" (both), e.g. "P3D~".
+// The qualifier is returned separately from the parsed value; unqualified
+// input yields a zero Qualifier.
+func ParseQualified(s string, opts ...ParseOption) (time.Duration, Qualifier, error) {
+	c := newParseConfig(opts)
+	s = c.trim(s)
+
+	var q Qualifier
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case '~':
+			q = Approximate
+		case '?':
+			q = Uncertain
+		case '%':
+			q = Approximate | Uncertain
+		}
+		if q != 0 {
+			s = s[:n-1]
+		}
+	}
+
+	elems, err := c.elements(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sumDuration(elems), q, nil
+}
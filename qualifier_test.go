@@ -0,0 +1,62 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQualifiedGivenValid(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		q   Qualifier
+	}{
+		{"PT1H", time.Hour, 0},
+		{"PT1H~", time.Hour, Approximate},
+		{"P3D?", 3 * dayTime, Uncertain},
+		{"P1Y%", yearTime, Approximate | Uncertain},
+		{" PT0.5S~ ", 500 * time.Millisecond, Approximate},
+	}
+
+	for _, vec := range vecs {
+		d, q, err := ParseQualified(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+		assert.Equal(t, vec.q, q, vec.in)
+	}
+}
+
+func TestParseQualifiedGivenInvalid(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		err error
+	}{
+		{"~", ErrBadFormat},
+		{"~PT1H", ErrBadFormat},
+		{"PT1H~~", ErrBadFormat},
+		{"PT1H~?", ErrBadFormat},
+		{"P1M~", ErrNoMonth},
+	}
+
+	for _, vec := range vecs {
+		d, q, err := ParseQualified(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, time.Duration(0), d, vec.in)
+		assert.Equal(t, Qualifier(0), q, vec.in)
+	}
+}
+
+func TestQualifierString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", Qualifier(0).String())
+	assert.Equal(t, "~", Approximate.String())
+	assert.Equal(t, "?", Uncertain.String())
+	assert.Equal(t, "%", (Approximate | Uncertain).String())
+}
@@ -0,0 +1,242 @@
+package duration
+
+import (
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A RepeatingInterval is an ISO8601 recurring time interval, an interval
+// repeated a number of times or without end.
+type RepeatingInterval struct {
+	// Repeats is the number of repetitions, or -1 if they are unbounded.
+	Repeats int
+
+	// Interval is the interval repeated. For a duration alone, such as the
+	// "PT10M" of "R/PT10M", Start and End are zero and only Period is set.
+	Interval Interval
+}
+
+// ParseRepeatingInterval parses an ISO8601 recurring time interval,
+// "Rn/interval" with n repetitions, such as "R5/2008-03-01T13:00:00Z/P1D", or
+// "R/interval" with unbounded repetitions. The interval is in any form
+// accepted by ParseInterval without an open end, or a duration alone as
+// accepted by ParseComponents, such as the "PT10M" of "R/PT10M". Counts that
+// do not fit in an int fail with ErrRange, and other invalid values with
+// ErrBadFormat. Surrounding white space is ignored. Options restrict the
+// interval repeated as they do for ParseInterval.
+func ParseRepeatingInterval(s string, opts ...IntervalOption) (RepeatingInterval, error) {
+	s = strings.TrimSpace(s)
+	head, rest, ok := strings.Cut(s, "/")
+	if !ok || !strings.HasPrefix(head, "R") {
+		return RepeatingInterval{}, ErrBadFormat
+	}
+
+	r := RepeatingInterval{Repeats: -1}
+	if n := head[1:]; n != "" {
+		if skipDigits(n, 0) != len(n) {
+			return RepeatingInterval{}, ErrBadFormat
+		}
+		v, err := strconv.Atoi(n)
+		if err != nil {
+			return RepeatingInterval{}, ErrRange
+		}
+		r.Repeats = v
+	}
+
+	var err error
+	if strings.Contains(rest, "/") {
+		if r.Interval, err = ParseInterval(rest, opts...); err == nil && (r.Interval.Start.IsZero() || r.Interval.End.IsZero()) {
+			err = ErrBadFormat
+		}
+	} else if strings.HasPrefix(rest, "P") && strings.TrimSpace(rest) == rest {
+		c := newIntervalConfig(opts)
+		if r.Interval.Period, err = c.period(rest); err == nil && c.maxSpan > 0 {
+			var d time.Duration
+			if d, err = r.Interval.Period.Duration(); err == nil && d > c.maxSpan {
+				err = ErrRange
+			}
+		}
+	} else {
+		err = ErrBadFormat
+	}
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	return r, nil
+}
+
+// StartingAt returns r with its interval starting at t, ending Period after
+// it, for repeating a duration alone (e.g. "R/PT10M") from a chosen time. The
+// Period of r must be set.
+func (r RepeatingInterval) StartingAt(t time.Time) (RepeatingInterval, error) {
+	end, err := r.Interval.Period.AddTo(t)
+	if err != nil {
+		return RepeatingInterval{}, err
+	}
+	r.Interval.Start, r.Interval.End = t, end
+	return r, nil
+}
+
+// In returns r with the start and end of its interval in loc, so that
+// repetitions of a Period with days or weeks keep the wall-clock time of the
+// start in loc, as a named time zone, across daylight saving time changes.
+// Otherwise they keep the UTC offset the start was written with, drifting by
+// an hour against local time when it changes: for example
+// "R/2021-03-26T09:00:00+01:00/P1D" repeats at 09:00 in Berlin on 28 March
+// with In, but at 10:00 without. When r has a Period, the end is computed
+// again from the start in loc.
+func (r RepeatingInterval) In(loc *time.Location) RepeatingInterval {
+	i := &r.Interval
+	if !i.Start.IsZero() {
+		i.Start = i.Start.In(loc)
+		if end, err := i.Period.AddTo(i.Start); err == nil && i.Period != (Components{}) {
+			i.End = end
+		}
+	}
+	if !i.End.IsZero() {
+		i.End = i.End.In(loc)
+	}
+	return r
+}
+
+// Occurrences returns an iterator over the start times of the repetitions of
+// r, generated as they are requested: the start of the interval, then each
+// Period after it, or each Duration of the interval for one written as a
+// start and end. The nth start is Period times n added to the first with
+// Components.AddTo, so that repeating P1M from January 31 gives the last day
+// of each month rather than drifting to the 28th; see In for days in a named
+// time zone. The iterator stops after Repeats starts, before the first start
+// not before until unless until is zero, when a start cannot be computed, and
+// after the first start if the interval has no length. It yields nothing if r
+// has no start; see StartingAt. Options stop the iterator earlier; with
+// MaxOccurrences or MaxHorizon, a schedule from an untrusted client can be
+// iterated without bounding until.
+func (r RepeatingInterval) Occurrences(until time.Time, opts ...RecurrenceOption) iter.Seq[time.Time] {
+	c := newRecurrenceConfig(opts)
+	return func(yield func(time.Time) bool) {
+		if r.Interval.Start.IsZero() {
+			return
+		}
+		for n := 0; r.Repeats < 0 || n < r.Repeats; n++ {
+			t, ok := r.occurrence(n)
+			if !ok || (!until.IsZero() && !t.Before(until)) || !c.allows(n, r.Interval.Start, t) || !yield(t) {
+				return
+			}
+			if !r.Interval.End.After(r.Interval.Start) {
+				return
+			}
+		}
+	}
+}
+
+// NextAfter returns the start of the first repetition of r after t, and
+// whether there is one. Unlike iterating over Occurrences, it computes the
+// start directly, however many repetitions precede it.
+func (r RepeatingInterval) NextAfter(t time.Time) (time.Time, bool) {
+	n, ok := r.index(t)
+	if !ok {
+		return time.Time{}, false
+	}
+	if n++; (r.Repeats >= 0 && n >= r.Repeats) || (n > 0 && r.step() <= 0) {
+		return time.Time{}, false
+	}
+	return r.occurrence(n)
+}
+
+// PrevBefore returns the start of the last repetition of r before t, and
+// whether there is one. Like NextAfter, it computes the start directly.
+func (r RepeatingInterval) PrevBefore(t time.Time) (time.Time, bool) {
+	n, ok := r.index(t)
+	if !ok || n < 0 {
+		return time.Time{}, false
+	}
+	if o, ok := r.occurrence(n); ok && o.Before(t) {
+		return o, true
+	}
+	if n == 0 {
+		return time.Time{}, false
+	}
+	return r.occurrence(n - 1)
+}
+
+// index returns the number of the last repetition of r that starts no later
+// than t, or -1 if none does, and false if r has no start or no repetitions.
+// The number is estimated from the nominal length of a repetition and then
+// corrected by searching outwards from the estimate, so that only a few starts
+// are computed when the estimate is close, as it is for all but the longest
+// schedules.
+func (r RepeatingInterval) index(t time.Time) (int, bool) {
+	start := r.Interval.Start
+	if start.IsZero() || r.Repeats == 0 {
+		return 0, false
+	}
+	if t.Before(start) {
+		return -1, true
+	}
+	step := r.step()
+	if step <= 0 {
+		return 0, true
+	}
+
+	last := math.MaxInt - 1
+	if r.Repeats > 0 {
+		last = r.Repeats - 1
+	}
+	starts := func(n int) bool {
+		o, ok := r.occurrence(n)
+		return ok && !o.After(t)
+	}
+
+	// find lo <= hi with starts(lo) and, unless hi is past the last
+	// repetition, !starts(hi)
+	secs := float64(t.Unix()-start.Unix()) + float64(t.Nanosecond()-start.Nanosecond())/1e9
+	n := min(int(min(secs/step, 1<<53)), last)
+	lo, hi := n, n
+	if starts(n) {
+		for d := 1; hi <= last && starts(hi); d *= 2 {
+			lo, hi = hi, hi+min(d, last+1-hi)
+		}
+	} else {
+		for d := 1; lo > 0 && !starts(lo); d *= 2 {
+			lo, hi = max(lo-d, 0), lo
+		}
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if starts(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, true
+}
+
+// step returns the nominal length of a repetition of r in seconds, with
+// months of an average 30.436875 days.
+func (r RepeatingInterval) step() float64 {
+	p := r.Interval.Period
+	if p == (Components{}) {
+		return r.Interval.Duration().Seconds()
+	}
+	return (p.Years*12+p.Months)*2629746 + (p.Weeks*7+p.Days)*86400 + p.Hours*3600 + p.Minutes*60 + p.Seconds
+}
+
+// occurrence returns the start of the nth repetition of r, counting from 0,
+// and whether it can be computed.
+func (r RepeatingInterval) occurrence(n int) (time.Time, bool) {
+	i := r.Interval
+	if i.Period == (Components{}) {
+		d := i.Duration()
+		if d != 0 && int64(n) > math.MaxInt64/int64(d) {
+			return time.Time{}, false
+		}
+		return i.Start.Add(time.Duration(n) * d), true
+	}
+
+	t, err := i.Period.scale(float64(n)).AddTo(i.Start)
+	return t, err == nil
+}
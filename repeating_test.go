@@ -0,0 +1,268 @@
+package duration
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepeatingInterval(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out RepeatingInterval
+		err error
+	}{
+		{
+			"R5/2008-03-01T13:00:00Z/P1D",
+			RepeatingInterval{5, Interval{
+				Start:  time.Date(2008, 3, 1, 13, 0, 0, 0, time.UTC),
+				End:    time.Date(2008, 3, 2, 13, 0, 0, 0, time.UTC),
+				Period: Components{Days: 1},
+			}},
+			nil,
+		},
+		{
+			"R2/2008-03-01T13:00:00Z/2008-03-01T14:00:00Z",
+			RepeatingInterval{2, Interval{
+				Start: time.Date(2008, 3, 1, 13, 0, 0, 0, time.UTC),
+				End:   time.Date(2008, 3, 1, 14, 0, 0, 0, time.UTC),
+			}},
+			nil,
+		},
+		{
+			"R/P1W/2008-03-08T00:00:00Z",
+			RepeatingInterval{-1, Interval{
+				Start:  time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2008, 3, 8, 0, 0, 0, 0, time.UTC),
+				Period: Components{Weeks: 1},
+			}},
+			nil,
+		},
+		{"R/PT10M", RepeatingInterval{-1, Interval{Period: Components{Minutes: 10}}}, nil},
+		{"R0/PT10M", RepeatingInterval{0, Interval{Period: Components{Minutes: 10}}}, nil},
+		{" R3/P1M\n", RepeatingInterval{3, Interval{Period: Components{Months: 1}}}, nil},
+
+		{"R", RepeatingInterval{}, ErrBadFormat},
+		{"R/", RepeatingInterval{}, ErrBadFormat},
+		{"R5", RepeatingInterval{}, ErrBadFormat},
+		{"5/PT10M", RepeatingInterval{}, ErrBadFormat},
+		{"R-1/PT10M", RepeatingInterval{}, ErrBadFormat},
+		{"Rx/PT10M", RepeatingInterval{}, ErrBadFormat},
+		{"R/2008-03-01T13:00:00Z", RepeatingInterval{}, ErrBadFormat},
+		{"R/PT10M ", RepeatingInterval{-1, Interval{Period: Components{Minutes: 10}}}, nil},
+		{"R/ PT10M", RepeatingInterval{}, ErrBadFormat},
+		{"R/P1D/P1D", RepeatingInterval{}, ErrBadFormat},
+		{"R/2008-03-02/2008-03-01", RepeatingInterval{}, ErrRange},
+		{"R99999999999999999999/PT10M", RepeatingInterval{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		r, err := ParseRepeatingInterval(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, r, vec.in)
+	}
+}
+
+func TestParseRepeatingIntervalOptions(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []IntervalOption
+		err  error
+	}{
+		{"R5/2008-03-01T13:00:00Z/P1D", []IntervalOption{RequireOffset(), ExactPeriod()}, nil},
+		{"R5/2008-03-01T13:00:00/P1D", []IntervalOption{RequireOffset()}, ErrBadFormat},
+		{"R/2008-03-01T13:00:00Z/P1M", []IntervalOption{ExactPeriod()}, ErrNoMonth},
+		{"R/P1Y", []IntervalOption{ExactPeriod()}, ErrNoYear},
+		{"R/PT10M", []IntervalOption{ExactPeriod(), MaxSpan(time.Hour)}, nil},
+		{"R/PT2H", []IntervalOption{MaxSpan(time.Hour)}, ErrRange},
+		{"R/2008-03-01T13:00:00Z/PT2H", []IntervalOption{MaxSpan(time.Hour)}, ErrRange},
+	}
+	for _, vec := range vecs {
+		_, err := ParseRepeatingInterval(vec.in, vec.opts...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestRepeatingIntervalStartingAt(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseRepeatingInterval("R/PT10M")
+	assert.NoError(t, err)
+
+	start := time.Date(2008, 3, 1, 13, 0, 0, 0, time.UTC)
+	r, err = r.StartingAt(start)
+	assert.NoError(t, err)
+	assert.Equal(t, start, r.Interval.Start)
+	assert.Equal(t, start.Add(10*time.Minute), r.Interval.End)
+
+	r.Interval.Period = Components{Months: 0.5}
+	_, err = r.StartingAt(start)
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestRepeatingIntervalOccurrences(t *testing.T) {
+	t.Parallel()
+
+	date := func(m time.Month, d, h int) time.Time {
+		return time.Date(2008, m, d, h, 0, 0, 0, time.UTC)
+	}
+	vecs := []struct {
+		in    string
+		until time.Time
+		out   []time.Time
+	}{
+		{"R3/2008-03-01T13:00:00Z/P1D", time.Time{}, []time.Time{date(3, 1, 13), date(3, 2, 13), date(3, 3, 13)}},
+		{"R/2008-03-01T13:00:00Z/P1D", date(3, 3, 13), []time.Time{date(3, 1, 13), date(3, 2, 13)}},
+		{"R5/2008-03-01T13:00:00Z/P1D", date(3, 3, 0), []time.Time{date(3, 1, 13), date(3, 2, 13)}},
+		{"R4/2008-01-31/P1M", time.Time{}, []time.Time{date(1, 31, 0), date(2, 29, 0), date(3, 31, 0), date(4, 30, 0)}},
+		{"R3/2008-03-01T00:00:00Z/2008-03-01T06:00:00Z", time.Time{}, []time.Time{date(3, 1, 0), date(3, 1, 6), date(3, 1, 12)}},
+		{"R3/PT6H/2008-03-01T06:00:00Z", time.Time{}, []time.Time{date(3, 1, 0), date(3, 1, 6), date(3, 1, 12)}},
+		{"R/2008-03-01/2008-03-01", time.Time{}, []time.Time{date(3, 1, 0)}},
+		{"R0/2008-03-01/P1D", time.Time{}, nil},
+		{"R/2008-03-01/P1D", date(3, 1, 0), nil},
+		{"R/PT10M", time.Time{}, nil},
+		{"R/2008-03-01/P100000000Y", time.Time{}, []time.Time{date(3, 1, 0), time.Date(100002008, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		{"R3/2008-03-01/P3000000Y", time.Time{}, []time.Time{date(3, 1, 0), time.Date(3002008, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(6002008, 3, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, vec := range vecs {
+		r, err := ParseRepeatingInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, slices.Collect(r.Occurrences(vec.until)), vec.in)
+	}
+
+	// unbounded iterators stop when the caller does
+	r, err := ParseRepeatingInterval("R/2008-03-01T00:00:00Z/PT1H")
+	assert.NoError(t, err)
+	var n int
+	for range r.Occurrences(time.Time{}) {
+		if n++; n == 100 {
+			break
+		}
+	}
+	assert.Equal(t, 100, n)
+}
+
+func TestRepeatingIntervalOccurrencesLimits(t *testing.T) {
+	t.Parallel()
+
+	date := func(s int) time.Time {
+		return time.Date(2008, 3, 1, 0, 0, s, 0, time.UTC)
+	}
+	vecs := []struct {
+		in   string
+		opts []RecurrenceOption
+		out  []time.Time
+	}{
+		{"R999999999/2008-03-01T00:00:00Z/PT1S", []RecurrenceOption{MaxOccurrences(3)}, []time.Time{date(0), date(1), date(2)}},
+		{"R/2008-03-01T00:00:00Z/PT1S", []RecurrenceOption{MaxHorizon(2 * time.Second)}, []time.Time{date(0), date(1), date(2)}},
+		{"R/2008-03-01T00:00:00Z/PT1S", []RecurrenceOption{MaxOccurrences(5), MaxHorizon(time.Second)}, []time.Time{date(0), date(1)}},
+		{"R2/2008-03-01T00:00:00Z/PT1S", []RecurrenceOption{MaxOccurrences(5)}, []time.Time{date(0), date(1)}},
+		{"R/2008-03-01T00:00:00Z/PT1S", []RecurrenceOption{MaxHorizon(time.Second / 2)}, []time.Time{date(0)}},
+	}
+
+	for _, vec := range vecs {
+		r, err := ParseRepeatingInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, slices.Collect(r.Occurrences(time.Time{}, vec.opts...)), vec.in)
+	}
+}
+
+func TestRepeatingIntervalNextAfter(t *testing.T) {
+	t.Parallel()
+
+	date := func(y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+	}
+	vecs := []struct {
+		in   string
+		t    time.Time
+		next time.Time
+		prev time.Time
+	}{
+		{"R/2008-03-01T00:00:00Z/PT1H", date(2008, 3, 1, 0), date(2008, 3, 1, 1), time.Time{}},
+		{"R/2008-03-01T00:00:00Z/PT1H", date(2008, 2, 1, 0), date(2008, 3, 1, 0), time.Time{}},
+		{"R/2008-03-01T00:00:00Z/PT1H", date(2008, 3, 1, 5).Add(time.Second), date(2008, 3, 1, 6), date(2008, 3, 1, 5)},
+		{"R/2008-03-01T00:00:00Z/PT1H", date(2108, 3, 1, 5), date(2108, 3, 1, 6), date(2108, 3, 1, 4)},
+		{"R/2008-03-01T00:00:00Z/PT10M", date(2200, 1, 1, 0).Add(-time.Nanosecond), date(2200, 1, 1, 0), date(2199, 12, 31, 23).Add(50 * time.Minute)},
+		{"R/2008-01-31/P1M", date(2008, 2, 29, 12), date(2008, 3, 31, 0), date(2008, 2, 29, 0)},
+		{"R/2008-01-31/P1M", date(3008, 2, 15, 0), date(3008, 2, 29, 0), date(3008, 1, 31, 0)},
+		{"R/2008-01-31/P1Y", date(9008, 2, 15, 0), date(9009, 1, 31, 0), date(9008, 1, 31, 0)},
+		{"R3/2008-03-01T00:00:00Z/PT1H", date(2008, 3, 1, 1), date(2008, 3, 1, 2), date(2008, 3, 1, 0)},
+		{"R3/2008-03-01T00:00:00Z/PT1H", date(2008, 3, 1, 2), time.Time{}, date(2008, 3, 1, 1)},
+		{"R3/2008-03-01T00:00:00Z/PT1H", date(2009, 1, 1, 0), time.Time{}, date(2008, 3, 1, 2)},
+		{"R3/2008-03-01T00:00:00Z/2008-03-01T02:00:00Z", date(2008, 3, 1, 3), date(2008, 3, 1, 4), date(2008, 3, 1, 2)},
+		{"R/2008-03-01/2008-03-01", date(2008, 2, 1, 0), date(2008, 3, 1, 0), time.Time{}},
+		{"R/2008-03-01/2008-03-01", date(2008, 3, 2, 0), time.Time{}, date(2008, 3, 1, 0)},
+		{"R0/2008-03-01/P1D", date(2008, 2, 1, 0), time.Time{}, time.Time{}},
+		{"R/PT1H", date(2008, 2, 1, 0), time.Time{}, time.Time{}},
+		{"R/2008-03-01/P1M", date(200000000, 1, 1, 0), time.Time{}, date(178958978, 10, 1, 0)},
+	}
+
+	for _, vec := range vecs {
+		r, err := ParseRepeatingInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+
+		next, ok := r.NextAfter(vec.t)
+		assert.Equal(t, !vec.next.IsZero(), ok, "%s: %v", vec.in, vec.t)
+		assert.True(t, vec.next.Equal(next), "%s: next after %v is %v", vec.in, vec.t, next)
+
+		prev, ok := r.PrevBefore(vec.t)
+		assert.Equal(t, !vec.prev.IsZero(), ok, "%s: %v", vec.in, vec.t)
+		assert.True(t, vec.prev.Equal(prev), "%s: prev before %v is %v", vec.in, vec.t, prev)
+	}
+}
+
+func TestRepeatingIntervalIn(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	r, err := ParseRepeatingInterval("R4/2021-03-26T09:00:00+01:00/P1D")
+	require.NoError(t, err)
+
+	utc := func(d, h int) time.Time {
+		return time.Date(2021, 3, d, h, 0, 0, 0, time.UTC)
+	}
+	var fixed []time.Time
+	for o := range r.Occurrences(time.Time{}) {
+		fixed = append(fixed, o.UTC())
+	}
+	assert.Equal(t, []time.Time{utc(26, 8), utc(27, 8), utc(28, 8), utc(29, 8)}, fixed)
+
+	r = r.In(berlin)
+	assert.Equal(t, berlin, r.Interval.Start.Location())
+	var local []time.Time
+	for o := range r.Occurrences(time.Time{}) {
+		assert.Equal(t, 9, o.Hour(), o)
+		local = append(local, o.UTC())
+	}
+	assert.Equal(t, []time.Time{utc(26, 8), utc(27, 8), utc(28, 7), utc(29, 7)}, local)
+
+	next, ok := r.NextAfter(utc(28, 0))
+	assert.True(t, ok)
+	assert.True(t, utc(28, 7).Equal(next), next)
+
+	// the first repetition ends at the same wall-clock time
+	r, err = ParseRepeatingInterval("R/2021-03-27T09:00:00+01:00/P1D")
+	require.NoError(t, err)
+	r = r.In(berlin)
+	assert.True(t, utc(28, 7).Equal(r.Interval.End), r.Interval.End)
+
+	// repetitions of an exact length are unchanged
+	r, err = ParseRepeatingInterval("R/2021-03-27T09:00:00+01:00/PT24H")
+	require.NoError(t, err)
+	next, ok = r.In(berlin).NextAfter(utc(27, 8))
+	assert.True(t, ok)
+	assert.True(t, utc(28, 8).Equal(next), next)
+}
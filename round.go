@@ -0,0 +1,92 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// RoundingMode selects how a duration is rounded to a multiple of a unit. The
+// modes follow the names used by JavaScript's Temporal API.
+type RoundingMode int
+
+const (
+	// RoundHalfExpand rounds to the nearest multiple, with ties away from
+	// zero.
+	RoundHalfExpand RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest multiple, with ties to the even
+	// multiple.
+	RoundHalfEven
+
+	// RoundTrunc rounds towards zero.
+	RoundTrunc
+
+	// RoundExpand rounds away from zero.
+	RoundExpand
+
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+)
+
+// Round rounds d to a whole multiple of the nominal length of u (e.g.
+// "PT1H29M59.6S" to "PT1H30M" for Minute) using mode. Month has no fixed
+// length, so d is returned unchanged for it. A multiple beyond the range of
+// time.Duration is clamped to the nearest one within it.
+func Round(d time.Duration, u Unit, mode RoundingMode) time.Duration {
+	if u < 0 || u >= numUnits || u == Month {
+		return d
+	}
+	r, _ := roundTo(d, unitTimes[u], mode)
+	return r
+}
+
+// Truncate rounds d towards zero to a whole multiple of the nominal length of
+// u. It is shorthand for Round(d, u, RoundTrunc).
+func Truncate(d time.Duration, u Unit) time.Duration {
+	return Round(d, u, RoundTrunc)
+}
+
+// roundTo rounds d to a multiple of m, which must be positive. If that
+// multiple is beyond the range of time.Duration, it returns the nearest one
+// within it and false.
+func roundTo(d, m time.Duration, mode RoundingMode) (time.Duration, bool) {
+	q, r := d/m, d%m
+	if r == 0 {
+		return d, true
+	}
+	in := q
+
+	// away is the quotient's step away from zero
+	away := time.Duration(1)
+	if r < 0 {
+		away, r = -1, -r
+	}
+
+	switch mode {
+	case RoundHalfExpand:
+		if 2*r >= m {
+			q += away
+		}
+	case RoundHalfEven:
+		if 2*r > m || 2*r == m && q%2 != 0 {
+			q += away
+		}
+	case RoundExpand:
+		q += away
+	case RoundFloor:
+		if away < 0 {
+			q--
+		}
+	case RoundCeil:
+		if away > 0 {
+			q++
+		}
+	}
+	if q > math.MaxInt64/m || q < math.MinInt64/m {
+		return in * m, false
+	}
+	return q * m, true
+}
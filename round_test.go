@@ -0,0 +1,126 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTo(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		mode RoundingMode
+		out  time.Duration
+	}{
+		{90 * time.Second, RoundHalfExpand, 2 * time.Minute},
+		{89 * time.Second, RoundHalfExpand, time.Minute},
+		{-90 * time.Second, RoundHalfExpand, -2 * time.Minute},
+
+		{90 * time.Second, RoundHalfEven, 2 * time.Minute},
+		{150 * time.Second, RoundHalfEven, 2 * time.Minute},
+		{151 * time.Second, RoundHalfEven, 3 * time.Minute},
+		{-150 * time.Second, RoundHalfEven, -2 * time.Minute},
+
+		{119 * time.Second, RoundTrunc, time.Minute},
+		{-119 * time.Second, RoundTrunc, -time.Minute},
+
+		{61 * time.Second, RoundExpand, 2 * time.Minute},
+		{-61 * time.Second, RoundExpand, -2 * time.Minute},
+
+		{119 * time.Second, RoundFloor, time.Minute},
+		{-61 * time.Second, RoundFloor, -2 * time.Minute},
+
+		{61 * time.Second, RoundCeil, 2 * time.Minute},
+		{-119 * time.Second, RoundCeil, -time.Minute},
+
+		{2 * time.Minute, RoundExpand, 2 * time.Minute},
+	}
+
+	for _, vec := range vecs {
+		out, ok := roundTo(vec.in, time.Minute, vec.mode)
+		assert.True(t, ok, vec.in)
+		assert.Equal(t, vec.out, out, vec.in)
+	}
+}
+
+func TestRoundToOverflow(t *testing.T) {
+	t.Parallel()
+
+	maxYears := math.MaxInt64 / yearTime * yearTime
+	vecs := []struct {
+		in   time.Duration
+		mode RoundingMode
+		out  time.Duration
+		ok   bool
+	}{
+		{math.MaxInt64, RoundCeil, maxYears, false},
+		{math.MaxInt64, RoundExpand, maxYears, false},
+		{math.MaxInt64, RoundHalfExpand, maxYears, true},
+		{math.MaxInt64, RoundFloor, maxYears, true},
+		{math.MinInt64, RoundFloor, -maxYears, false},
+		{math.MinInt64, RoundExpand, -maxYears, false},
+		{math.MinInt64, RoundCeil, -maxYears, true},
+	}
+
+	for _, vec := range vecs {
+		out, ok := roundTo(vec.in, yearTime, vec.mode)
+		assert.Equal(t, vec.ok, ok, vec.in)
+		assert.Equal(t, vec.out, out, vec.in)
+	}
+
+	assert.Equal(t, maxYears, Round(math.MaxInt64, Year, RoundCeil))
+	assert.Equal(t, -maxYears, Round(math.MinInt64, Year, RoundFloor))
+
+	_, err := FormatWithOptions(math.MaxInt64, SmallestUnit(Year), Rounding(RoundCeil))
+	assert.ErrorIs(t, err, ErrRange)
+	_, err = FormatWithOptions(math.MinInt64+1, AllowNegative(), SmallestUnit(Year), Rounding(RoundExpand))
+	assert.ErrorIs(t, err, ErrRange)
+	s, err := FormatWithOptions(math.MaxInt64, SmallestUnit(Year), Rounding(RoundFloor))
+	assert.NoError(t, err)
+	assert.Equal(t, "P292Y", s)
+
+	_, err = Components{Seconds: math.MaxInt64 / 1e9}.Round(SmallestUnit(Day), Rounding(RoundCeil))
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestRound(t *testing.T) {
+	t.Parallel()
+
+	d := time.Hour + 29*time.Minute + 59600*time.Millisecond
+
+	vecs := []struct {
+		unit Unit
+		mode RoundingMode
+		out  time.Duration
+	}{
+		{Second, RoundHalfExpand, time.Hour + 30*time.Minute},
+		{Minute, RoundHalfExpand, time.Hour + 30*time.Minute},
+		{Minute, RoundTrunc, time.Hour + 29*time.Minute},
+		{Hour, RoundHalfExpand, time.Hour},
+		{Hour, RoundCeil, 2 * time.Hour},
+		{Day, RoundCeil, dayTime},
+		{Week, RoundTrunc, 0},
+		{Month, RoundHalfExpand, d},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, Round(d, vec.unit, vec.mode), vec.unit)
+	}
+
+	s, err := Format(Round(d, Minute, RoundHalfExpand))
+	assert.NoError(t, err)
+	assert.Equal(t, "PT1H30M", s)
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Hour+29*time.Minute, Truncate(time.Hour+29*time.Minute+59*time.Second, Minute))
+	assert.Equal(t, -time.Hour, Truncate(-time.Hour-59*time.Minute, Hour))
+	assert.Equal(t, 2*dayTime, Truncate(2*dayTime+23*time.Hour, Day))
+	assert.Equal(t, time.Second, Truncate(time.Second, Month))
+}
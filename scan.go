@@ -0,0 +1,150 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// element is a single number/designator pair from a duration string.
+type element struct {
+	unit    Unit
+	whole   int64
+	frac    float64
+	hasFrac bool
+
+	// pos and end are the byte offsets of the element in the scanned string
+	pos, end int
+}
+
+func (e element) duration() time.Duration {
+	t := unitTimes[e.unit]
+	d := time.Duration(e.whole) * t
+	if e.frac != 0 {
+		d += time.Duration(e.frac * float64(t))
+	}
+	return d
+}
+
+func (e element) seconds() float64 {
+	t := unitTimes[e.unit].Seconds()
+	return float64(e.whole)*t + e.frac*t
+}
+
+func (e element) value() float64 {
+	return float64(e.whole) + e.frac
+}
+
+func sumDuration(elems []element) time.Duration {
+	var d time.Duration
+	for _, e := range elems {
+		d += e.duration()
+	}
+	return d
+}
+
+// scan splits an ISO8601 duration string into its elements, in the order they
+// appear, and reports whether the string has a leading "-" sign. If signed is
+// set, a leading "+" and a sign on each element are accepted too. It checks
+// only the lexical structure of the string; ordering and other semantic rules
+// are left to the caller.
+func scan(s string, signed bool) (elems []element, neg bool, err error) {
+	start := 1
+	if strings.HasPrefix(s, "-") {
+		neg, start = true, 2
+	} else if signed && strings.HasPrefix(s, "+") {
+		start = 2
+	}
+	if !strings.HasPrefix(s[start-1:], "P") {
+		return nil, false, ErrBadFormat
+	}
+
+	var inTime bool
+
+	for i := start; i < len(s); {
+		if s[i] == 'T' {
+			if inTime {
+				return nil, false, ErrBadFormat
+			}
+			inTime = true
+			i++
+			continue
+		}
+
+		pos, minus := i, false
+		if signed && (s[i] == '-' || s[i] == '+') {
+			minus = s[i] == '-'
+			i++
+		}
+
+		j := skipDigits(s, i)
+		if j == i {
+			return nil, false, ErrBadFormat
+		}
+		if j < len(s) && (s[j] == '.' || s[j] == ',') {
+			k := skipDigits(s, j+1)
+			if k == j+1 {
+				return nil, false, ErrBadFormat
+			}
+			j = k
+		}
+		if j == len(s) {
+			return nil, false, ErrBadFormat
+		}
+
+		u, ok := designator(s[j], inTime)
+		if !ok {
+			return nil, false, ErrBadFormat
+		}
+
+		whole, frac, hasFrac, err := parseDecimal(s[i:j])
+		if err != nil {
+			return nil, false, ErrBadFormat
+		}
+
+		if minus {
+			whole, frac = -whole, -frac
+		}
+
+		elems = append(elems, element{u, whole, frac, hasFrac, pos, j + 1})
+		i = j + 1
+	}
+
+	// There must be at least one element in the string
+	if len(elems) == 0 {
+		return nil, false, ErrBadFormat
+	}
+
+	return elems, neg, nil
+}
+
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+func designator(c byte, inTime bool) (Unit, bool) {
+	if inTime {
+		switch c {
+		case 'H':
+			return Hour, true
+		case 'M':
+			return Minute, true
+		case 'S':
+			return Second, true
+		}
+	} else {
+		switch c {
+		case 'Y':
+			return Year, true
+		case 'M':
+			return Month, true
+		case 'W':
+			return Week, true
+		case 'D':
+			return Day, true
+		}
+	}
+	return 0, false
+}
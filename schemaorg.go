@@ -0,0 +1,29 @@
+package duration
+
+import "errors"
+
+// schemaOrgTimes are the schema.org properties holding how long a task takes,
+// for which a month element is almost always a mistake for minutes.
+var schemaOrgTimes = map[string]bool{
+	"cookTime": true, "prepTime": true, "performTime": true, "totalTime": true,
+}
+
+// ValidateSchemaOrg checks value, a duration for the schema.org property
+// named by property (e.g. "duration" or "cookTime"), for use in microdata or
+// JSON-LD. Any ISO8601 duration accepted by ParseComponents is valid, so that
+// calendar periods such as "P3M" for a course pass, and surrounding white
+// space, which markup often carries, is ignored. For the times of recipes and
+// how-to steps (cookTime, prepTime, performTime and totalTime), a month
+// element fails with a *MonthError suggesting minutes instead, catching the
+// common mistake of "P30M" for "PT30M".
+func ValidateSchemaOrg(property, value string) error {
+	if _, err := ParseComponents(value); err != nil {
+		return err
+	}
+	if schemaOrgTimes[property] {
+		if _, err := Parse(value); errors.Is(err, ErrNoMonth) {
+			return err
+		}
+	}
+	return nil
+}
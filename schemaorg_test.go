@@ -0,0 +1,43 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaOrg(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		property string
+		in       string
+		err      error
+	}{
+		{"cookTime", "PT1H30M", nil},
+		{"prepTime", " PT15M ", nil},
+		{"totalTime", "P1DT2H", nil},
+		{"duration", "PT2M30S", nil},
+		{"duration", "P3M", nil},
+		{"timeRequired", "P2M", nil},
+		{"cookTime", "P1Y", nil},
+		{"cookTime", "P30M", ErrNoMonth},
+		{"performTime", "P1Y2M", ErrNoMonth},
+		{"cookTime", "30 minutes", ErrBadFormat},
+		{"duration", "PT1H30", ErrBadFormat},
+		{"duration", "-PT1H", ErrBadFormat},
+		{"duration", "", ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		err := ValidateSchemaOrg(vec.property, vec.in)
+		assert.ErrorIs(t, err, vec.err, "%s %s", vec.property, vec.in)
+	}
+
+	var merr *MonthError
+	err := ValidateSchemaOrg("cookTime", "P30M")
+	if assert.True(t, errors.As(err, &merr)) {
+		assert.Equal(t, "PT30M", merr.Suggestion)
+	}
+}
@@ -0,0 +1,75 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseSCORM parses the session and total times recorded by e-learning
+// content: either the CMITimespan of SCORM 1.2, "HHHH:MM:SS.SS" with two to
+// four digits of hours and an optional fraction of one or two digits (e.g.
+// "0001:30:05.25"), or the ISO8601 timeinterval of SCORM 2004 (e.g.
+// "PT1H30M5.25S"), read as by Parse. Surrounding white space is ignored.
+// Timeintervals with month elements (e.g. "P1Y2M3DT4H"), which SCORM 2004
+// allows but which have no fixed length, fail with ErrNoMonth; use
+// ParseSCORMComponents to read them.
+func ParseSCORM(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ":") {
+		return Parse(s)
+	}
+	return parseCMITimespan(s)
+}
+
+// ParseSCORMComponents is like ParseSCORM but returns the elements of a
+// SCORM 2004 timeinterval as written, read as by ParseComponents, so that
+// month elements are accepted. A SCORM 1.2 CMITimespan is returned as split
+// by Split.
+func ParseSCORMComponents(s string) (Components, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ":") {
+		return ParseComponents(s)
+	}
+	d, err := parseCMITimespan(s)
+	if err != nil {
+		return Components{}, err
+	}
+	return Split(d), nil
+}
+
+// parseCMITimespan parses the CMITimespan of SCORM 1.2.
+func parseCMITimespan(s string) (time.Duration, error) {
+	if strings.Contains(s, ",") {
+		return 0, ErrBadFormat
+	}
+
+	clock, frac, hasFrac := strings.Cut(s, ".")
+	fields := strings.Split(clock, ":")
+	if len(fields) != 3 || len(fields[0]) < 2 || len(fields[0]) > 4 || skipDigits(fields[0], 0) != len(fields[0]) {
+		return 0, ErrBadFormat
+	}
+	if hasFrac && (frac == "" || len(frac) > 2 || skipDigits(frac, 0) != len(frac)) {
+		return 0, ErrBadFormat
+	}
+	return ParseClock(s)
+}
+
+// FromSCORM converts a SCORM session or total time, as accepted by
+// ParseSCORMComponents, to ISO8601, as written by FormatWithOptions with opts.
+// Times with month elements are written as by FormatComponents instead, with
+// their elements as given. Pass Precision(2) and TrimZeros to write the
+// timeinterval of SCORM 2004, which allows at most two fractional digits.
+func FromSCORM(s string, opts ...FormatOption) (string, error) {
+	c, err := ParseSCORMComponents(s)
+	if err != nil {
+		return "", err
+	}
+	if c.Months != 0 {
+		return FormatComponents(c, opts...)
+	}
+	d, err := c.Duration()
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
@@ -0,0 +1,91 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSCORM(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"0001:30:05.25", time.Hour + 30*time.Minute + 5250*time.Millisecond, nil},
+		{"00:00:30", 30 * time.Second, nil},
+		{"9999:59:59.9", 9999*time.Hour + 59*time.Minute + 59900*time.Millisecond, nil},
+		{"PT1H30M5.25S", time.Hour + 30*time.Minute + 5250*time.Millisecond, nil},
+		{"P1DT2H", 26 * time.Hour, nil},
+		{" 00:01:00\n", time.Minute, nil},
+
+		{"", 0, ErrBadFormat},
+		{"1:30:05", 0, ErrBadFormat},
+		{"00001:30:05", 0, ErrBadFormat},
+		{"30:05", 0, ErrBadFormat},
+		{"00:30:05.255", 0, ErrBadFormat},
+		{"00:30:05.", 0, ErrBadFormat},
+		{"00:30:05,25", 0, ErrBadFormat},
+		{"-00:30:05", 0, ErrBadFormat},
+		{"00:3:05", 0, ErrBadFormat},
+		{"00:60:00", 0, ErrRange},
+		{"P1M", 0, ErrNoMonth},
+		{"P1Y2M3DT4H", 0, ErrNoMonth},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseSCORM(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestParseSCORMComponents(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Components
+		err error
+	}{
+		{"P1Y2M3DT4H", Components{Years: 1, Months: 2, Days: 3, Hours: 4}, nil},
+		{" P1M ", Components{Months: 1}, nil},
+		{"PT90M", Components{Minutes: 90}, nil},
+		{"0026:30:05.25", Components{Days: 1, Hours: 2, Minutes: 30, Seconds: 5.25}, nil},
+
+		{"", Components{}, ErrBadFormat},
+		{"1:30:05", Components{}, ErrBadFormat},
+		{"00:60:00", Components{}, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParseSCORMComponents(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, c, vec.in)
+	}
+}
+
+func TestFromSCORM(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		opts []FormatOption
+		out  string
+	}{
+		{"0001:30:05.25", nil, "PT1H30M5.250S"},
+		{"0001:30:05.25", []FormatOption{Precision(2), TrimZeros()}, "PT1H30M5.25S"},
+		{"00:00:00", []FormatOption{ZeroAs(ZeroSeconds)}, "PT0S"},
+		{"PT90M", nil, "PT1H30M"},
+		{"P1Y2M3DT4H", nil, "P1Y2M3DT4H"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FromSCORM(vec.in, vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+	}
+}
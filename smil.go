@@ -0,0 +1,92 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smilMetrics maps the metrics of SMIL timecount values to their lengths.
+var smilMetrics = map[string]time.Duration{
+	"h": time.Hour, "min": time.Minute, "s": time.Second, "ms": time.Millisecond,
+}
+
+// ParseSMIL parses a clock value of SMIL, as used for the timing attributes of
+// SVG animations. Clock values are either a full or partial clock, such as
+// "02:30:03" or "02:33.5", with any number of digits of hours and exactly two
+// of minutes and seconds, or a timecount with an optional metric of "h",
+// "min", "s" or "ms", such as "3.2h", "45min" or "30" (seconds). Fractions
+// use "." only, and clock values have no sign. Minutes and seconds of 60 or
+// more fail with ErrRange. Surrounding white space is ignored.
+func ParseSMIL(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "-+,") {
+		return 0, ErrBadFormat
+	}
+	if strings.Contains(s, ":") {
+		return parseSMILClock(s)
+	}
+
+	j := skipDigits(s, 0)
+	if j == 0 {
+		return 0, ErrBadFormat
+	}
+	if j < len(s) && s[j] == '.' {
+		k := skipDigits(s, j+1)
+		if k == j+1 {
+			return 0, ErrBadFormat
+		}
+		j = k
+	}
+	n, err := strconv.ParseFloat(s[:j], 64)
+	if err != nil {
+		return 0, ErrRange
+	}
+
+	t := time.Second
+	if metric := s[j:]; metric != "" {
+		var ok bool
+		if t, ok = smilMetrics[metric]; !ok {
+			return 0, ErrBadFormat
+		}
+	}
+
+	v := math.Round(n * float64(t))
+	if v >= math.MaxInt64 {
+		return 0, ErrRange
+	}
+	return time.Duration(v), nil
+}
+
+// FromSMIL converts a SMIL clock value, as accepted by ParseSMIL, to ISO8601,
+// as written by FormatWithOptions with opts.
+func FromSMIL(s string, opts ...FormatOption) (string, error) {
+	d, err := ParseSMIL(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
+
+// parseSMILClock parses a full or partial clock value of SMIL.
+func parseSMILClock(s string) (time.Duration, error) {
+	clock, frac, hasFrac := strings.Cut(s, ".")
+	if hasFrac && (frac == "" || skipDigits(frac, 0) != len(frac)) {
+		return 0, ErrBadFormat
+	}
+
+	fields := strings.Split(clock, ":")
+	if len(fields) > 3 {
+		return 0, ErrBadFormat
+	}
+	for i, f := range fields {
+		if f == "" || skipDigits(f, 0) != len(f) || (i > 0 || len(fields) == 2) && len(f) != 2 {
+			return 0, ErrBadFormat
+		}
+		if (i > 0 || len(fields) == 2) && f >= "60" {
+			return 0, ErrRange
+		}
+	}
+	return ParseClock(s)
+}
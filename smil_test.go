@@ -0,0 +1,77 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSMIL(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+		err error
+	}{
+		{"02:30:03", 2*time.Hour + 30*time.Minute + 3*time.Second, nil},
+		{"50:00:10.25", 50*time.Hour + 10250*time.Millisecond, nil},
+		{"02:33", 2*time.Minute + 33*time.Second, nil},
+		{"00:10.5", 10500 * time.Millisecond, nil},
+		{"2:30:03", 2*time.Hour + 30*time.Minute + 3*time.Second, nil},
+		{"123:00:00", 123 * time.Hour, nil},
+		{"3.2h", 3*time.Hour + 12*time.Minute, nil},
+		{"45min", 45 * time.Minute, nil},
+		{"30s", 30 * time.Second, nil},
+		{"5ms", 5 * time.Millisecond, nil},
+		{"12.467", 12467 * time.Millisecond, nil},
+		{" 30s ", 30 * time.Second, nil},
+		{"0", 0, nil},
+
+		{"", 0, ErrBadFormat},
+		{"s", 0, ErrBadFormat},
+		{".5s", 0, ErrBadFormat},
+		{"5.s", 0, ErrBadFormat},
+		{"5 s", 0, ErrBadFormat},
+		{"5m", 0, ErrBadFormat},
+		{"1h30min", 0, ErrBadFormat},
+		{"-5s", 0, ErrBadFormat},
+		{"+5s", 0, ErrBadFormat},
+		{"00:10,5", 0, ErrBadFormat},
+		{"PT5S", 0, ErrBadFormat},
+		{"123:45", 0, ErrBadFormat},
+		{"2:33", 0, ErrBadFormat},
+		{"02:3", 0, ErrBadFormat},
+		{"02:30:3", 0, ErrBadFormat},
+		{"02:030:03", 0, ErrBadFormat},
+		{"01:02:30:03", 0, ErrBadFormat},
+		{"02:33.", 0, ErrBadFormat},
+		{":33", 0, ErrBadFormat},
+		{"02:60", 0, ErrRange},
+		{"75:00", 0, ErrRange},
+		{"02:75:00", 0, ErrRange},
+		{"3000000h", 0, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseSMIL(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+}
+
+func TestFromSMIL(t *testing.T) {
+	t.Parallel()
+
+	s, err := FromSMIL("02:30:03")
+	assert.NoError(t, err)
+	assert.Equal(t, "PT2H30M3S", s)
+
+	s, err = FromSMIL("45min", SecondsOnly())
+	assert.NoError(t, err)
+	assert.Equal(t, "PT2700S", s)
+
+	_, err = FromSMIL("45 minutes")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
@@ -0,0 +1,138 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// A Speller writes durations out in words for one language. SpellOut uses
+// English unless another Speller is given with the SpellWith option.
+type Speller interface {
+	// SpellPart returns the words for a single part, e.g. "thirty minutes"
+	// for Part{Minute, "30"}.
+	SpellPart(p Part) string
+
+	// Join combines the spelled-out parts of a duration, most significant
+	// first, into a phrase, e.g. "one hour thirty minutes". neg reports
+	// whether the duration is negative.
+	Join(parts []string, neg bool) string
+}
+
+// English is the Speller used by SpellOut by default.
+var English Speller = english{}
+
+// SpellWith selects the Speller used by SpellOut.
+func SpellWith(sp Speller) FormatOption {
+	return formatOptionFunc(func(c *formatConfig) { c.speller = sp })
+}
+
+// SpellOut returns d written out in words, such as "one hour thirty minutes",
+// for text-to-speech and other spoken output. It accepts the same options as
+// Humanize, and SpellWith to select a language other than English.
+func SpellOut(d time.Duration, opts ...FormatOption) string {
+	sp := newFormatConfig(opts).speller
+	if sp == nil {
+		sp = English
+	}
+
+	parts := Parts(d, opts...)
+	words := make([]string, len(parts))
+	for i, p := range parts {
+		words[i] = sp.SpellPart(p)
+	}
+	return sp.Join(words, d < 0)
+}
+
+type english struct{}
+
+func (english) SpellPart(p Part) string {
+	var s strings.Builder
+	whole, frac, _ := strings.Cut(p.Value, ".")
+	s.WriteString(spellEnglish(whole))
+	if frac != "" {
+		s.WriteString(" point")
+		for _, c := range frac {
+			s.WriteByte(' ')
+			s.WriteString(englishOnes[c-'0'])
+		}
+	}
+	s.WriteByte(' ')
+	s.WriteString(p.Unit.String())
+	if p.Value != "1" {
+		s.WriteByte('s')
+	}
+	return s.String()
+}
+
+func (english) Join(parts []string, neg bool) string {
+	s := strings.Join(parts, " ")
+	if neg {
+		s = "minus " + s
+	}
+	return s
+}
+
+var (
+	englishOnes = [...]string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	englishTens = [...]string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+	englishScales = [...]string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+	}
+)
+
+// spellEnglish spells out the decimal digits in s, e.g. "one hundred twenty"
+// for "120".
+func spellEnglish(s string) string {
+	s = strings.TrimLeft(s, "0")
+	if s == "" {
+		return englishOnes[0]
+	}
+
+	var groups []string
+	for scale := 0; s != ""; scale++ {
+		i := max(len(s)-3, 0)
+		n := 0
+		for _, c := range s[i:] {
+			n = n*10 + int(c-'0')
+		}
+		s = s[:i]
+		if n == 0 {
+			continue
+		}
+
+		g := spellHundreds(n)
+		if scale > 0 {
+			g += " " + englishScales[scale]
+		}
+		groups = append(groups, g)
+	}
+
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+	return strings.Join(groups, " ")
+}
+
+// spellHundreds spells out n, which must be between 1 and 999.
+func spellHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, englishOnes[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		words = append(words, englishTens[n/10]+"-"+englishOnes[n%10])
+	case n >= 20:
+		words = append(words, englishTens[n/10])
+	case n > 0:
+		words = append(words, englishOnes[n])
+	}
+	return strings.Join(words, " ")
+}
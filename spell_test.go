@@ -0,0 +1,74 @@
+package duration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpellOut(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   time.Duration
+		opts []FormatOption
+		out  string
+	}{
+		{0, nil, "zero seconds"},
+		{time.Second, nil, "one second"},
+		{90 * time.Minute, nil, "one hour thirty minutes"},
+		{-90 * time.Minute, nil, "minus one hour thirty minutes"},
+		{1500 * time.Millisecond, nil, "one point five seconds"},
+		{1500 * time.Millisecond, []FormatOption{DecimalSeparator(SeparatorComma)}, "one point five seconds"},
+		{2*dayTime + 21*time.Hour + 45*time.Minute, nil, "two days twenty-one hours forty-five minutes"},
+		{115 * time.Hour, []FormatOption{LargestUnit(Hour)}, "one hundred fifteen hours"},
+		{1_000_017 * time.Second, []FormatOption{SecondsOnly()}, "one million seventeen seconds"},
+		{90 * time.Minute, []FormatOption{MaxComponents(1)}, "one point five hours"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, SpellOut(vec.in, vec.opts...), vec.in)
+	}
+}
+
+type upperSpeller struct{}
+
+func (upperSpeller) SpellPart(p Part) string {
+	return strings.ToUpper(English.SpellPart(p))
+}
+
+func (upperSpeller) Join(parts []string, neg bool) string {
+	return strings.Join(parts, " AND ")
+}
+
+func TestSpellOutGivenSpellWith(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ONE HOUR AND THIRTY MINUTES", SpellOut(90*time.Minute, SpellWith(upperSpeller{})))
+}
+
+func TestSpellEnglish(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out string
+	}{
+		{"0", "zero"},
+		{"007", "seven"},
+		{"13", "thirteen"},
+		{"40", "forty"},
+		{"99", "ninety-nine"},
+		{"100", "one hundred"},
+		{"1000", "one thousand"},
+		{"1001", "one thousand one"},
+		{"2562047", "two million five hundred sixty-two thousand forty-seven"},
+		{"9223372036", "nine billion two hundred twenty-three million three hundred seventy-two thousand thirty-six"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, spellEnglish(vec.in), vec.in)
+	}
+}
@@ -0,0 +1,71 @@
+package duration
+
+// Remainder selects what Interval.SplitBy does with the time left at the end
+// of an interval that is not a whole multiple of the length of a part.
+type Remainder int
+
+const (
+	// RemainderKeep ends with a shorter part covering the time left. This is
+	// the zero value.
+	RemainderKeep Remainder = iota
+
+	// RemainderDrop leaves out the time left, so that all parts have the full
+	// length.
+	RemainderDrop
+
+	// RemainderExtend ends with a part of the full length that extends past
+	// the end of the interval.
+	RemainderExtend
+)
+
+// SplitBy splits i into consecutive parts of length d from its start, such as
+// the pages of a reporting window, with the time left at the end handled as
+// rem says. The nth boundary is d times n added to the start with
+// Components.AddTo, so that parts of P1M from January 31 end on the last day
+// of each month. Parts of the full length have d as their Period. Intervals
+// with an open end, and lengths that do not move forward from the start of
+// the interval, fail with ErrRange; lengths that AddTo rejects fail as it
+// does. So do splits into more parts than options allow, as soon as the limit
+// is passed, so that a long interval split into short parts (e.g. a year into
+// seconds) cannot exhaust memory.
+func (i Interval) SplitBy(d Components, rem Remainder, opts ...RecurrenceOption) ([]Interval, error) {
+	if i.Start.IsZero() || i.End.IsZero() {
+		return nil, ErrRange
+	}
+	c := newRecurrenceConfig(opts)
+	first := i.Start
+	r := RepeatingInterval{Repeats: -1, Interval: Interval{Start: i.Start, Period: d}}
+	next, err := d.AddTo(i.Start)
+	if err != nil {
+		return nil, err
+	}
+	if !next.After(i.Start) {
+		return nil, ErrRange
+	}
+
+	var parts []Interval
+	for n := 2; i.Start.Before(i.End); n++ {
+		if (!next.After(i.End) || rem != RemainderDrop) && !c.allows(n-2, first, i.Start) {
+			return nil, ErrRange
+		}
+		if next.After(i.End) {
+			switch rem {
+			case RemainderKeep:
+				parts = append(parts, Interval{Start: i.Start, End: i.End})
+			case RemainderExtend:
+				parts = append(parts, Interval{Start: i.Start, End: next, Period: d})
+			}
+			break
+		}
+		parts = append(parts, Interval{Start: i.Start, End: next, Period: d})
+		if i.Start = next; !i.Start.Before(i.End) {
+			break
+		}
+
+		var ok bool
+		if next, ok = r.occurrence(n); !ok || !next.After(i.Start) {
+			return nil, ErrRange
+		}
+	}
+	return parts, nil
+}
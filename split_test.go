@@ -0,0 +1,104 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalSplitBy(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		d   Components
+		rem Remainder
+		out []string
+		err error
+	}{
+		{
+			"2020-01-01/2020-01-04", Components{Days: 1}, RemainderKeep,
+			[]string{"2020-01-01/P1D", "2020-01-02/P1D", "2020-01-03/P1D"}, nil,
+		},
+		{
+			"2020-01-01/2020-01-04T12:00", Components{Days: 1}, RemainderKeep,
+			[]string{"2020-01-01/P1D", "2020-01-02/P1D", "2020-01-03/P1D", "2020-01-04/2020-01-04T12:00"}, nil,
+		},
+		{
+			"2020-01-01/2020-01-04T12:00", Components{Days: 1}, RemainderDrop,
+			[]string{"2020-01-01/P1D", "2020-01-02/P1D", "2020-01-03/P1D"}, nil,
+		},
+		{
+			"2020-01-01/2020-01-04T12:00", Components{Days: 1}, RemainderExtend,
+			[]string{"2020-01-01/P1D", "2020-01-02/P1D", "2020-01-03/P1D", "2020-01-04/P1D"}, nil,
+		},
+		{
+			"2020-01-31/2020-05-01", Components{Months: 1}, RemainderKeep,
+			[]string{"2020-01-31/P1M", "2020-02-29/2020-03-31", "2020-03-31/2020-04-30", "2020-04-30/2020-05-01"}, nil,
+		},
+		{
+			"2020-01-01T00:00Z/PT10M", Components{Hours: 1}, RemainderKeep,
+			[]string{"2020-01-01T00:00Z/PT10M"}, nil,
+		},
+		{"2020-01-01T00:00Z/PT10M", Components{Hours: 1}, RemainderDrop, nil, nil},
+		{"2020-01-01/2020-01-01", Components{Days: 1}, RemainderKeep, nil, nil},
+		{"2020-01-01/..", Components{Days: 1}, RemainderKeep, nil, ErrRange},
+		{"2020-01-01/2020-01-04", Components{}, RemainderKeep, nil, ErrRange},
+		{"2020-01-01/2020-01-04", Components{Days: -1}, RemainderKeep, nil, ErrRange},
+		{"2020-01-01/2020-01-04", Components{Months: 0.5}, RemainderKeep, nil, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+
+		parts, err := i.SplitBy(vec.d, vec.rem)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+
+		var out []Interval
+		for _, s := range vec.out {
+			p, err := ParseInterval(s)
+			assert.NoError(t, err, s)
+			out = append(out, p)
+		}
+		assert.Equal(t, len(out), len(parts), vec.in)
+		for j := range min(len(out), len(parts)) {
+			assert.True(t, out[j].Start.Equal(parts[j].Start) && out[j].End.Equal(parts[j].End), "%s: %v", vec.in, parts[j])
+		}
+	}
+}
+
+func TestIntervalSplitByLimits(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   string
+		rem  Remainder
+		opts []RecurrenceOption
+		n    int
+		err  error
+	}{
+		{"2020-01-01/2020-01-04", RemainderKeep, []RecurrenceOption{MaxOccurrences(3)}, 3, nil},
+		{"2020-01-01/2020-01-04T12:00", RemainderKeep, []RecurrenceOption{MaxOccurrences(3)}, 0, ErrRange},
+		{"2020-01-01/2020-01-04T12:00", RemainderDrop, []RecurrenceOption{MaxOccurrences(3)}, 3, nil},
+		{"2020-01-01/2020-01-04T12:00", RemainderExtend, []RecurrenceOption{MaxHorizon(72 * time.Hour)}, 4, nil},
+		{"2020-01-01/2020-01-04T12:00", RemainderExtend, []RecurrenceOption{MaxHorizon(48 * time.Hour)}, 0, ErrRange},
+		{"2020-01-01/2021-01-01", RemainderKeep, []RecurrenceOption{MaxOccurrences(100)}, 0, ErrRange},
+	}
+
+	for _, vec := range vecs {
+		i, err := ParseInterval(vec.in)
+		assert.NoError(t, err, vec.in)
+
+		parts, err := i.SplitBy(Components{Days: 1}, vec.rem, vec.opts...)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Len(t, parts, vec.n, vec.in)
+	}
+
+	// a year is not split into seconds when the parts would exceed the limit
+	i, err := ParseInterval("2020-01-01/2021-01-01")
+	assert.NoError(t, err)
+	_, err = i.SplitBy(Components{Seconds: 1}, RemainderKeep, MaxOccurrences(1000))
+	assert.ErrorIs(t, err, ErrRange)
+}
@@ -0,0 +1,57 @@
+package duration
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Value implements driver.Valuer, storing d as an ISO8601 string.
+func (d Duration) Value() (driver.Value, error) {
+	b, err := signedFormat.append(nil, time.Duration(d))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner. It accepts an ISO8601 string or an interval
+// string as accepted by ParsePostgres (e.g. "1 day 01:30:00", or "01:30:00" as
+// returned for TIME columns by many drivers), an integer number of
+// nanoseconds, or a floating-point number of seconds. NULL scans as zero.
+// Intervals with months fail with ErrNoMonth.
+func (d *Duration) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = 0
+	case int64:
+		*d = Duration(v)
+	case float64:
+		f, err := fromSeconds(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(f)
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into duration.Duration: %w", src, ErrBadFormat)
+	}
+	return nil
+}
+
+func (d *Duration) scanString(s string) error {
+	v, err := parseSigned(s)
+	if err == ErrBadFormat {
+		if c, perr := ParsePostgres(s); perr == nil {
+			v, err = c.Duration()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
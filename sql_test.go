@@ -0,0 +1,77 @@
+package duration
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = (*Duration)(nil)
+	_ driver.Valuer = Duration(0)
+)
+
+func TestDurationValue(t *testing.T) {
+	t.Parallel()
+
+	v, err := Duration(90 * time.Minute).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "PT1H30M", v)
+
+	v, err = Duration(-time.Second).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-PT1S", v)
+
+	_, err = Duration(math.MinInt64).Value()
+	assert.ErrorIs(t, err, ErrRange)
+}
+
+func TestDurationScan(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  any
+		out Duration
+	}{
+		{"PT1H30M", Duration(90 * time.Minute)},
+		{[]byte("-P1D"), Duration(-dayTime)},
+		{"01:30:00", Duration(90 * time.Minute)},
+		{[]byte("838:59:59"), Duration(838*time.Hour + 59*time.Minute + 59*time.Second)},
+		{"-00:00:01.5", Duration(-1500 * time.Millisecond)},
+		{"1 day 02:00:00", Duration(26 * time.Hour)},
+		{"-1 days +02:00:00", Duration(-22 * time.Hour)},
+		{int64(1500), Duration(1500)},
+		{float64(1.5), Duration(1500 * time.Millisecond)},
+		{nil, 0},
+	}
+
+	for _, vec := range vecs {
+		d := Duration(time.Minute)
+		assert.NoError(t, d.Scan(vec.in), vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  any
+		err error
+	}{
+		{"1h30m", ErrBadFormat},
+		{"P1M", ErrNoMonth},
+		{"01:60:00", ErrBadFormat},
+		{"01:30", ErrBadFormat},
+		{"1 mon", ErrNoMonth},
+		{true, ErrBadFormat},
+		{time.Now(), ErrBadFormat},
+		{1e10, ErrRange},
+		{math.NaN(), ErrRange},
+	}
+
+	for _, vec := range bad {
+		var d Duration
+		assert.ErrorIs(t, d.Scan(vec.in), vec.err, vec.in)
+	}
+}
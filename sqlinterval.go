@@ -0,0 +1,128 @@
+package duration
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sqlFields are the datetime fields of SQL interval qualifiers, in order.
+var sqlFields = map[string]Unit{
+	"YEAR":   Year,
+	"MONTH":  Month,
+	"DAY":    Day,
+	"HOUR":   Hour,
+	"MINUTE": Minute,
+	"SECOND": Second,
+}
+
+// ParseSQL parses an SQL:92 interval literal, such as
+// "INTERVAL '1-2' YEAR TO MONTH", "INTERVAL '3 04:05:06' DAY TO SECOND" or
+// "INTERVAL '90' MINUTE", into its elements. Any single field or valid range
+// of fields may be used as the qualifier, with precisions such as "DAY(3)".
+// The leading field may be any size; the others must be less than the next
+// larger field (e.g. hours less than 24), otherwise ErrRange is returned. Use
+// FormatComponents to convert the result to ISO8601.
+func ParseSQL(s string) (Components, error) {
+	v, qual, ok, err := splitInterval(s)
+	if err != nil {
+		return Components{}, err
+	}
+	if !ok {
+		return Components{}, ErrBadFormat
+	}
+
+	var lead, trail Unit
+	switch {
+	case len(qual) == 1:
+		if lead, ok = sqlFields[strings.ToUpper(qual[0])]; !ok {
+			return Components{}, ErrBadFormat
+		}
+		trail = lead
+	case len(qual) == 3 && strings.EqualFold(qual[1], "TO"):
+		var ok1, ok2 bool
+		lead, ok1 = sqlFields[strings.ToUpper(qual[0])]
+		trail, ok2 = sqlFields[strings.ToUpper(qual[2])]
+		// Year-month and day-time fields cannot be mixed
+		if !ok1 || !ok2 || lead >= trail || (lead <= Month) != (trail <= Month) {
+			return Components{}, ErrBadFormat
+		}
+	default:
+		return Components{}, ErrBadFormat
+	}
+
+	neg, v := intervalSign(v)
+
+	var c Components
+	for u := lead; u <= trail; u++ {
+		if u == Week {
+			continue
+		}
+		if u != lead {
+			sep := byte(':')
+			switch u {
+			case Month:
+				sep = '-'
+			case Hour:
+				sep = ' '
+			}
+			if v == "" || v[0] != sep {
+				return Components{}, ErrBadFormat
+			}
+			v = v[1:]
+		}
+
+		i := skipDigits(v, 0)
+		if i == 0 {
+			return Components{}, ErrBadFormat
+		}
+		if u == Second && i < len(v) && v[i] == '.' {
+			j := skipDigits(v, i+1)
+			if j == i+1 {
+				return Components{}, ErrBadFormat
+			}
+			i = j
+		}
+
+		n, err := strconv.ParseFloat(v[:i], 64)
+		if err != nil {
+			return Components{}, ErrRange
+		}
+		if u != lead && n >= sqlLimits[u] {
+			return Components{}, ErrRange
+		}
+		if neg {
+			n = -n
+		}
+		*c.field(u) = n
+		v = v[i:]
+	}
+
+	if v != "" {
+		return Components{}, ErrBadFormat
+	}
+	return c, nil
+}
+
+// sqlLimits are the bounds on the non-leading fields of an interval value.
+var sqlLimits = [numUnits]float64{Month: 12, Hour: 24, Minute: 60, Second: 60}
+
+// FormatSQL returns c as an SQL:92 interval literal. Components with only
+// years and months are written as YEAR TO MONTH (e.g.
+// "INTERVAL '1-2' YEAR TO MONTH"), as from FormatOracleYearMonth; others are
+// written as DAY TO SECOND (e.g. "INTERVAL '3 04:05:06' DAY TO SECOND"), as
+// from FormatOracleDaySecond, since Oracle uses the standard syntax for both.
+// SQL intervals cannot mix the two kinds of field, so components with years
+// or months and any other field fail with ErrBadFormat. DAY TO SECOND values
+// are converted with Components.Duration, so those too long for a
+// time.Duration fail with ErrRange.
+func FormatSQL(c Components) (string, error) {
+	if c.Years != 0 || c.Months != 0 {
+		return FormatOracleYearMonth(c)
+	}
+
+	d, err := c.Duration()
+	if err != nil {
+		return "", err
+	}
+	return FormatOracleDaySecond(d), nil
+}
@@ -0,0 +1,101 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSQL(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Components
+	}{
+		{"INTERVAL '1-2' YEAR TO MONTH", Components{Years: 1, Months: 2}},
+		{"INTERVAL '3 04:05:06' DAY TO SECOND", Components{Days: 3, Hours: 4, Minutes: 5, Seconds: 6}},
+		{"INTERVAL '3 04:05:06.5' DAY(3) TO SECOND(1)", Components{Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}},
+		{"interval '90' minute", Components{Minutes: 90}},
+		{"INTERVAL '1.25' SECOND", Components{Seconds: 1.25}},
+		{"INTERVAL '15' MONTH", Components{Months: 15}},
+		{"INTERVAL '3 04' DAY TO HOUR", Components{Days: 3, Hours: 4}},
+		{"INTERVAL '3 04:05' DAY TO MINUTE", Components{Days: 3, Hours: 4, Minutes: 5}},
+		{"INTERVAL '36:30' HOUR TO MINUTE", Components{Hours: 36, Minutes: 30}},
+		{"INTERVAL '1:02:03' HOUR TO SECOND", Components{Hours: 1, Minutes: 2, Seconds: 3}},
+		{"INTERVAL '100:30' MINUTE TO SECOND", Components{Minutes: 100, Seconds: 30}},
+		{"INTERVAL '-1-6' YEAR TO MONTH", Components{Years: -1, Months: -6}},
+		{"INTERVAL '+2' DAY", Components{Days: 2}},
+	}
+
+	for _, vec := range vecs {
+		c, err := ParseSQL(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, c, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"'1-2' YEAR TO MONTH", ErrBadFormat},
+		{"INTERVAL '1-2'", ErrBadFormat},
+		{"INTERVAL '1-2' YEAR TO DAY", ErrBadFormat},
+		{"INTERVAL '1 2' DAY TO MONTH", ErrBadFormat},
+		{"INTERVAL '1:2' MINUTE TO HOUR", ErrBadFormat},
+		{"INTERVAL '1' WEEK", ErrBadFormat},
+		{"INTERVAL '1' YEAR TO YEAR", ErrBadFormat},
+		{"INTERVAL '1:02' DAY TO MINUTE", ErrBadFormat},
+		{"INTERVAL '1.5' MINUTE", ErrBadFormat},
+		{"INTERVAL '1 02:03:04' DAY TO MINUTE", ErrBadFormat},
+		{"INTERVAL '1-12' YEAR TO MONTH", ErrRange},
+		{"INTERVAL '1 24' DAY TO HOUR", ErrRange},
+		{"INTERVAL '1:60' HOUR TO MINUTE", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseSQL(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestFormatSQL(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  Components
+		out string
+	}{
+		{Components{Years: 1, Months: 2}, "INTERVAL '1-2' YEAR TO MONTH"},
+		{Components{Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, "INTERVAL '3 04:05:06' DAY TO SECOND"},
+		{Components{Minutes: 90}, "INTERVAL '0 01:30:00' DAY TO SECOND"},
+		{Components{Weeks: 1}, "INTERVAL '7 00:00:00' DAY TO SECOND"},
+		{Components{}, "INTERVAL '0 00:00:00' DAY TO SECOND"},
+	}
+
+	for _, vec := range vecs {
+		s, err := FormatSQL(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, s, vec.in)
+
+		c, err := ParseSQL(s)
+		assert.NoError(t, err, s)
+		d1, _ := c.Duration()
+		d2, _ := vec.in.Duration()
+		if vec.in.Months == 0 {
+			assert.Equal(t, d2, d1, s)
+		}
+	}
+
+	for _, in := range []Components{{Years: 1, Days: 1}, {Months: 1, Seconds: 1}, {Seconds: math.NaN()}} {
+		_, err := FormatSQL(in)
+		assert.ErrorIs(t, err, ErrBadFormat, in)
+	}
+	for _, in := range []Components{{Days: 200000}, {Days: -200000}, {Weeks: 1, Seconds: 9223372036}} {
+		s, err := FormatSQL(in)
+		assert.ErrorIs(t, err, ErrRange, in)
+		assert.Empty(t, s)
+	}
+}
@@ -0,0 +1,94 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// systemdUnits maps the unit names of systemd time spans to their lengths.
+// systemd months and years are an average 30.44 and 365.25 days respectively.
+// Microseconds may be written with either the micro sign (U+00B5) or the Greek
+// letter mu (U+03BC).
+var systemdUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nsec": time.Nanosecond,
+	"us": time.Microsecond, "usec": time.Microsecond, "\u00b5s": time.Microsecond, "\u03bcs": time.Microsecond,
+	"ms": time.Millisecond, "msec": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": dayTime, "day": dayTime, "days": dayTime,
+	"w": weekTime, "week": weekTime, "weeks": weekTime,
+	"M": 2629800 * time.Second, "month": 2629800 * time.Second, "months": 2629800 * time.Second,
+	"y": 31557600 * time.Second, "year": 31557600 * time.Second, "years": 31557600 * time.Second,
+}
+
+// ParseSystemd parses a time span in the syntax of systemd unit files, such as
+// "2h 30min", "1 week 3 days" or "1.5s". As in systemd, the elements may appear
+// in any order and are summed, white space between them is optional, and a
+// number without a unit is a number of seconds. Months and years are the
+// averages used by systemd, 30.44 and 365.25 days. The span "infinity" fails
+// with ErrRange.
+func ParseSystemd(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrBadFormat
+	}
+	if s == "infinity" {
+		return 0, ErrRange
+	}
+
+	var d time.Duration
+	for s != "" {
+		j := skipDigits(s, 0)
+		if j < len(s) && s[j] == '.' {
+			k := skipDigits(s, j+1)
+			if k == j+1 {
+				return 0, ErrBadFormat
+			}
+			j = k
+		}
+		if j == 0 {
+			return 0, ErrBadFormat
+		}
+		n, err := strconv.ParseFloat(s[:j], 64)
+		if err != nil {
+			return 0, ErrBadFormat
+		}
+		s = strings.TrimLeft(s[j:], " \t\n")
+
+		k := strings.IndexFunc(s, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == '\n' || r == '.' || r >= '0' && r <= '9'
+		})
+		if k == -1 {
+			k = len(s)
+		}
+
+		t := time.Second
+		if k > 0 {
+			var ok bool
+			if t, ok = systemdUnits[s[:k]]; !ok {
+				return 0, ErrBadFormat
+			}
+		}
+		s = strings.TrimLeft(s[k:], " \t\n")
+
+		v := math.Round(n * float64(t))
+		if v >= float64(math.MaxInt64-d) {
+			return 0, ErrRange
+		}
+		d += time.Duration(v)
+	}
+	return d, nil
+}
+
+// FromSystemd converts a systemd time span, as accepted by ParseSystemd, to
+// ISO8601, as written by FormatWithOptions with opts.
+func FromSystemd(s string, opts ...FormatOption) (string, error) {
+	d, err := ParseSystemd(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatWithOptions(d, opts...)
+}
@@ -0,0 +1,83 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSystemd(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"2h 30min", 150 * time.Minute},
+		{"2h30min", 150 * time.Minute},
+		{"1 week 3 days", 10 * dayTime},
+		{"1 week3 days", 10 * dayTime},
+		{"30min 2h", 150 * time.Minute},
+		{"1.5s", 1500 * time.Millisecond},
+		{".5s", 500 * time.Millisecond},
+		{"90", 90 * time.Second},
+		{"5m 5", 5*time.Minute + 5*time.Second},
+		{"300ms20s 5day", 5*dayTime + 20300*time.Millisecond},
+		{"10 usec", 10 * time.Microsecond},
+		{"5us", 5 * time.Microsecond},
+		{"5\u00b5s", 5 * time.Microsecond},
+		{"5\u03bcs", 5 * time.Microsecond},
+		{"500ns", 500 * time.Nanosecond},
+		{"1s 500 nsec", time.Second + 500*time.Nanosecond},
+		{"1M", 2629800 * time.Second},
+		{"1y", 31557600 * time.Second},
+		{"0", 0},
+		{" 1h\n", time.Hour},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseSystemd(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"h", ErrBadFormat},
+		{"1 fortnight", ErrBadFormat},
+		{"-1h", ErrBadFormat},
+		{"1..5s", ErrBadFormat},
+		{"1h,30min", ErrBadFormat},
+		{"PT1H", ErrBadFormat},
+		{"infinity", ErrRange},
+		{"300y", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseSystemd(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestFromSystemd(t *testing.T) {
+	t.Parallel()
+
+	s, err := FromSystemd("2h 30min")
+	assert.NoError(t, err)
+	assert.Equal(t, "PT2H30M", s)
+
+	s, err = FromSystemd("1 week 3 days")
+	assert.NoError(t, err)
+	assert.Equal(t, "P10D", s)
+
+	s, err = FromSystemd("1y")
+	assert.NoError(t, err)
+	assert.Equal(t, "P1YT6H", s)
+
+	_, err = FromSystemd("forever")
+	assert.ErrorIs(t, err, ErrBadFormat)
+}
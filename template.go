@@ -0,0 +1,60 @@
+package duration
+
+import (
+	"fmt"
+	"time"
+)
+
+// TemplateFuncs returns functions for use with the Funcs method of
+// text/template and html/template templates:
+//
+//	iso       formats a duration as ISO8601, e.g. {{ .Timeout | iso }}
+//	isoHuman  humanizes a duration, e.g. {{ .Timeout | isoHuman }}
+//	isoRound  rounds to a unit and formats, e.g. {{ .Timeout | isoRound "minute" }}
+//
+// Each function accepts a time.Duration, a Duration, or an ISO8601 string. The
+// unit given to isoRound is a unit name such as "minute"; other names leave
+// the value unrounded.
+// None of them return errors, so a bad value cannot abort rendering: strings
+// that fail to parse are written unchanged, and other unsupported values are
+// written as with fmt.Sprint.
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"iso": func(v any) string {
+			return templateFormat(v, func(d time.Duration) string {
+				return Duration(d).String()
+			})
+		},
+		"isoHuman": func(v any) string {
+			return templateFormat(v, func(d time.Duration) string {
+				return Humanize(d)
+			})
+		},
+		"isoRound": func(unit string, v any) string {
+			return templateFormat(v, func(d time.Duration) string {
+				for u := Year; u < numUnits; u++ {
+					if u.String() == unit {
+						d = Round(d, u, RoundHalfExpand)
+					}
+				}
+				return Duration(d).String()
+			})
+		},
+	}
+}
+
+func templateFormat(v any, f func(time.Duration) string) string {
+	switch v := v.(type) {
+	case time.Duration:
+		return f(v)
+	case Duration:
+		return f(time.Duration(v))
+	case string:
+		d, err := Parse(v)
+		if err != nil {
+			return v
+		}
+		return f(d)
+	}
+	return fmt.Sprint(v)
+}
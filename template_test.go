@@ -0,0 +1,50 @@
+package duration
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		text string
+		data any
+		out  string
+	}{
+		{"{{ . | iso }}", 90 * time.Minute, "PT1H30M"},
+		{"{{ . | iso }}", Duration(time.Second), "PT1S"},
+		{"{{ . | iso }}", "PT90M", "PT1H30M"},
+		{"{{ . | isoHuman }}", "PT90M", "1 hour, 30 minutes"},
+		{"{{ . | isoRound \"minute\" }}", time.Hour + 29*time.Minute + 59600*time.Millisecond, "PT1H30M"},
+		{"{{ . | isoRound \"hour\" }}", "PT1H40M", "PT2H"},
+
+		// Bad values are written rather than failing the render
+		{"{{ . | iso }}", "P1M", "P1M"},
+		{"{{ . | isoHuman }}", 42, "42"},
+	}
+
+	for _, vec := range vecs {
+		tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(vec.text))
+
+		var s strings.Builder
+		assert.NoError(t, tmpl.Execute(&s, vec.data), vec.text)
+		assert.Equal(t, vec.out, s.String(), vec.text)
+	}
+}
+
+func TestTemplateFuncsHTML(t *testing.T) {
+	t.Parallel()
+
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(TemplateFuncs()).Parse(`<time datetime="{{ . | iso }}">{{ . | isoHuman }}</time>`))
+
+	var s strings.Builder
+	assert.NoError(t, tmpl.Execute(&s, 2*time.Hour))
+	assert.Equal(t, `<time datetime="PT2H">2 hours</time>`, s.String())
+}
@@ -0,0 +1,99 @@
+package duration
+
+import (
+	"math"
+	"time"
+)
+
+// Round rounds and balances c following the round method of JavaScript's
+// Temporal.Duration, so that results match those computed in browsers. The
+// options LargestUnit, SmallestUnit and Rounding play the parts of Temporal's
+// largestUnit, smallestUnit and roundingMode; other options are ignored.
+//
+// The duration is rounded to a multiple of the smallest unit, with ties away
+// from zero unless Rounding says otherwise, and then balanced into the units
+// from the largest unit down, with days of exactly 24 hours. Without
+// LargestUnit, or with LargestUnit(Year), the largest unit is the larger of
+// the smallest unit and the largest non-zero field of c. Without SmallestUnit
+// or Rounding, fractions of a second are kept. As in Temporal without a
+// relativeTo date, years, months and weeks have no fixed length, so components
+// with any of them fail with ErrRange, as do durations that round beyond the
+// range of time.Duration.
+func (c Components) Round(opts ...FormatOption) (Components, error) {
+	d, err := c.exact()
+	if err != nil {
+		return Components{}, err
+	}
+
+	fc := newFormatConfig(opts)
+	largest, smallest := fc.largest, fc.smallest
+	if largest == Year {
+		// LargestUnit defaults to the largest field in use
+		largest = smallest
+		for _, u := range formatUnits[1:] {
+			if *c.field(u) != 0 {
+				largest = min(largest, u)
+				break
+			}
+		}
+	}
+
+	if fc.rounding != nil || smallest != Second {
+		mode := RoundHalfExpand
+		if fc.rounding != nil {
+			mode = *fc.rounding
+		}
+		var ok bool
+		if d, ok = roundTo(d, unitTimes[smallest], mode); !ok {
+			return Components{}, ErrRange
+		}
+	}
+
+	var r Components
+	for _, u := range formatUnits[1:] {
+		if u < largest {
+			continue
+		}
+		t := unitTimes[u]
+		if u == smallest {
+			*r.field(u) = float64(d/t) + float64(d%t)/float64(t)
+			break
+		}
+		*r.field(u) = float64(d / t)
+		d %= t
+	}
+	return r, nil
+}
+
+// Total returns the length of c as a number of units u, following the total
+// method of JavaScript's Temporal.Duration, with days of exactly 24 hours
+// (e.g. 1.5 for "PT90M" in Hour). As for Round, components with years, months
+// or weeks, or a unit of Year, Month or Week, fail with ErrRange.
+func (c Components) Total(u Unit) (float64, error) {
+	if u < Day || u >= numUnits {
+		return 0, ErrRange
+	}
+	d, err := c.exact()
+	if err != nil {
+		return 0, err
+	}
+	t := unitTimes[u]
+	return float64(d/t) + float64(d%t)/float64(t), nil
+}
+
+// exact returns the exact length of c, which must have no years, months or
+// weeks.
+func (c Components) exact() (time.Duration, error) {
+	if c.Years != 0 || c.Months != 0 || c.Weeks != 0 {
+		return 0, ErrRange
+	}
+
+	var secs float64
+	for u := Day; u < numUnits; u++ {
+		secs += *c.field(u) * unitTimes[u].Seconds()
+	}
+	if math.IsNaN(secs) || math.Abs(secs) >= math.MaxInt64/float64(time.Second) {
+		return 0, ErrRange
+	}
+	return c.Duration()
+}
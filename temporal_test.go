@@ -0,0 +1,79 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentsRound(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   Components
+		opts []FormatOption
+		out  Components
+	}{
+		// Examples from the Temporal documentation
+		{Components{Hours: 130, Minutes: 20}, []FormatOption{LargestUnit(Day)}, Components{Days: 5, Hours: 10, Minutes: 20}},
+		{Components{Minutes: 80, Seconds: 130}, []FormatOption{LargestUnit(Hour)}, Components{Hours: 1, Minutes: 22, Seconds: 10}},
+		{Components{Hours: 1, Minutes: 30}, []FormatOption{SmallestUnit(Hour)}, Components{Hours: 2}},
+		{Components{Hours: 1, Minutes: 30}, []FormatOption{SmallestUnit(Hour), Rounding(RoundHalfEven)}, Components{Hours: 2}},
+		{Components{Hours: 2, Minutes: 30}, []FormatOption{SmallestUnit(Hour), Rounding(RoundHalfEven)}, Components{Hours: 2}},
+		{Components{Hours: 1, Minutes: 59}, []FormatOption{SmallestUnit(Hour), Rounding(RoundTrunc)}, Components{Hours: 1}},
+
+		// The largest unit defaults to the largest field in use
+		{Components{Minutes: 90}, []FormatOption{SmallestUnit(Minute)}, Components{Minutes: 90}},
+		{Components{Hours: 1, Minutes: 90}, nil, Components{Hours: 2, Minutes: 30}},
+		{Components{Seconds: 90.5}, nil, Components{Seconds: 90.5}},
+		{Components{Seconds: 90.5}, []FormatOption{Rounding(RoundHalfExpand)}, Components{Seconds: 91}},
+		{Components{Days: 1, Hours: 36}, nil, Components{Days: 2, Hours: 12}},
+		{Components{Days: 1, Hours: 36}, []FormatOption{LargestUnit(Hour)}, Components{Hours: 60}},
+
+		// Negative and mixed-sign components
+		{Components{Hours: -1, Minutes: -90}, nil, Components{Hours: -2, Minutes: -30}},
+		{Components{Hours: 1, Minutes: -90}, nil, Components{Minutes: -30}},
+		{Components{Minutes: -90}, []FormatOption{SmallestUnit(Hour), Rounding(RoundFloor)}, Components{Hours: -2}},
+	}
+
+	for _, vec := range vecs {
+		r, err := vec.in.Round(vec.opts...)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, r, vec.in)
+	}
+
+	for _, in := range []Components{{Years: 1}, {Months: 1}, {Weeks: 1}, {Seconds: 1e19}} {
+		_, err := in.Round()
+		assert.ErrorIs(t, err, ErrRange, in)
+	}
+}
+
+func TestComponentsTotal(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in   Components
+		unit Unit
+		out  float64
+	}{
+		{Components{Minutes: 90}, Hour, 1.5},
+		{Components{Days: 1, Hours: 12}, Day, 1.5},
+		{Components{Days: 1, Hours: 12}, Minute, 2160},
+		{Components{Seconds: 0.5}, Second, 0.5},
+		{Components{Hours: -1, Minutes: -30}, Hour, -1.5},
+		{Components{}, Second, 0},
+	}
+
+	for _, vec := range vecs {
+		f, err := vec.in.Total(vec.unit)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, f, vec.in)
+	}
+
+	for _, u := range []Unit{Year, Month, Week, -1, numUnits} {
+		_, err := Components{Hours: 1}.Total(u)
+		assert.ErrorIs(t, err, ErrRange, u)
+	}
+	_, err := Components{Weeks: 1}.Total(Day)
+	assert.ErrorIs(t, err, ErrRange)
+}
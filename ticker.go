@@ -0,0 +1,99 @@
+package duration
+
+import (
+	"sync"
+	"time"
+)
+
+// A Ticker delivers the starts of the repetitions of a RepeatingInterval on a
+// channel as they arrive, for running jobs on a schedule such as "R/PT5M".
+type Ticker struct {
+	// C receives the start of each repetition when it arrives. It is closed
+	// after the last repetition of a bounded schedule.
+	C <-chan time.Time
+
+	mu      sync.Mutex
+	stopped bool
+	stop    chan struct{}
+}
+
+// NewTicker returns a Ticker for the repetitions of r that start after the
+// call. Each wait is computed afresh from the wall clock with
+// RepeatingInterval.NextAfter rather than by adding up periods, so ticks do
+// not drift, and calendar periods such as P1M fire on their calendar dates.
+// As with time.Ticker, ticks are dropped if the receiver falls behind, and the
+// value sent is the scheduled start rather than the time of delivery.
+//
+// If r has no start, as for "R/PT5M", ticks fall on the multiples of the
+// period since the zero time, as by time.Time.Truncate (e.g. on the hour and
+// every five minutes after it in UTC); the period must then have no months or
+// years, failing with ErrNoMonth or ErrNoYear, and must be positive, failing
+// with ErrRange. Use RepeatingInterval.StartingAt to anchor other schedules.
+func NewTicker(r RepeatingInterval) (*Ticker, error) {
+	if r.Interval.Start.IsZero() {
+		if r.Interval.Period.Years != 0 {
+			return nil, ErrNoYear
+		}
+		d, err := r.Interval.Period.Duration()
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, ErrRange
+		}
+		if r, err = r.StartingAt(time.Now().Truncate(d)); err != nil {
+			return nil, err
+		}
+	}
+
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, stop: make(chan struct{})}
+	go t.run(r, c, time.Now())
+	return t, nil
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns, but C
+// is not closed, as for time.Ticker.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.stopped {
+		t.stopped = true
+		close(t.stop)
+	}
+}
+
+func (t *Ticker) run(r RepeatingInterval, c chan<- time.Time, after time.Time) {
+	for {
+		next, ok := r.NextAfter(after)
+		if !ok {
+			close(c)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-t.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		t.mu.Lock()
+		if t.stopped {
+			t.mu.Unlock()
+			return
+		}
+		select {
+		case c <- next:
+		default:
+		}
+		t.mu.Unlock()
+
+		// a clock set back must not repeat a tick
+		if after = time.Now(); after.Before(next) {
+			after = next
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicker(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseRepeatingInterval("R/PT0.02S")
+	require.NoError(t, err)
+	ticker, err := NewTicker(r)
+	require.NoError(t, err)
+	defer ticker.Stop()
+
+	var prev time.Time
+	for range 5 {
+		select {
+		case tick := <-ticker.C:
+			assert.Equal(t, tick, tick.Truncate(20*time.Millisecond))
+			if !prev.IsZero() {
+				assert.Zero(t, tick.Sub(prev)%(20*time.Millisecond))
+				assert.True(t, tick.After(prev))
+			}
+			prev = tick
+		case <-time.After(time.Second):
+			t.Fatal("no tick")
+		}
+	}
+
+	ticker.Stop()
+	ticker.Stop()
+	select {
+	case <-ticker.C:
+	default:
+	}
+	select {
+	case <-ticker.C:
+		t.Fatal("tick after Stop")
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestTickerBounded(t *testing.T) {
+	t.Parallel()
+
+	r := RepeatingInterval{Repeats: 3, Interval: Interval{Period: Components{Seconds: 0.01}}}
+	start := time.Now().Round(0).Add(10 * time.Millisecond)
+	r, err := r.StartingAt(start)
+	require.NoError(t, err)
+	ticker, err := NewTicker(r)
+	require.NoError(t, err)
+	defer ticker.Stop()
+
+	var ticks []time.Time
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case tick, ok := <-ticker.C:
+			if !ok {
+				done = true
+				break
+			}
+			ticks = append(ticks, tick)
+			assert.False(t, time.Now().Before(tick))
+		case <-timeout:
+			t.Fatal("ticker not closed")
+		}
+	}
+	assert.Equal(t, []time.Time{start, start.Add(10 * time.Millisecond), start.Add(20 * time.Millisecond)}, ticks)
+}
+
+func TestNewTickerError(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		err error
+	}{
+		{"R/P1M", ErrNoMonth},
+		{"R/P1Y", ErrNoYear},
+		{"R/PT0S", ErrRange},
+	}
+
+	for _, vec := range vecs {
+		r, err := ParseRepeatingInterval(vec.in)
+		require.NoError(t, err, vec.in)
+		_, err = NewTicker(r)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
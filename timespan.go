@@ -0,0 +1,116 @@
+package duration
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tick is the resolution of a .NET TimeSpan.
+const tick = 100 * time.Nanosecond
+
+// FormatTimeSpan returns d in the constant ("c") format of a .NET TimeSpan,
+// "[-][d.]hh:mm:ss[.fffffff]" (e.g. "1.02:03:04.5000000"), which
+// TimeSpan.Parse accepts. d is truncated to the 100ns resolution of a
+// TimeSpan.
+func FormatTimeSpan(d time.Duration) string {
+	var buf [32]byte
+	b := buf[:0]
+
+	n := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		n = uint64(-d)
+	}
+
+	if days := n / uint64(dayTime); days != 0 {
+		b = strconv.AppendUint(b, days, 10)
+		b = append(b, '.')
+	}
+	b = appendPadded(b, int64(n/uint64(time.Hour)%24), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(n/uint64(time.Minute)%60), 2)
+	b = append(b, ':')
+	b = appendPadded(b, int64(n/uint64(time.Second)%60), 2)
+	if ticks := n % uint64(time.Second) / uint64(tick); ticks != 0 {
+		b = append(b, '.')
+		b = appendPadded(b, int64(ticks), 7)
+	}
+	return string(b)
+}
+
+// ParseTimeSpan parses a duration in the constant ("c") format of a .NET
+// TimeSpan: "[-][d.]hh:mm[:ss[.fffffff]]" or a whole number of days "[-]d".
+// Hours must be less than 24 and minutes and seconds less than 60, and the
+// fraction may have at most seven digits, otherwise ErrRange is returned.
+// Surrounding white space is ignored. Use Format to convert the result to
+// ISO8601.
+func ParseTimeSpan(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	}
+
+	days, clock := s, ""
+	if i := strings.IndexAny(s, ".:"); i != -1 && s[i] == '.' {
+		days, clock = s[:i], s[i+1:]
+		if clock == "" {
+			return 0, ErrBadFormat
+		}
+	} else if i != -1 {
+		days, clock = "", s
+	}
+
+	var d time.Duration
+	if days != "" || clock == "" {
+		if days == "" || skipDigits(days, 0) != len(days) {
+			return 0, ErrBadFormat
+		}
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil || n > int64(math.MaxInt64/dayTime) {
+			return 0, ErrRange
+		}
+		d = time.Duration(n) * dayTime
+	}
+
+	if clock != "" {
+		hms, frac, hasFrac := strings.Cut(clock, ".")
+		fields := strings.Split(hms, ":")
+		if len(fields) < 2 || len(fields) > 3 || (hasFrac && (frac == "" || len(fields) != 3)) {
+			return 0, ErrBadFormat
+		}
+
+		for i, f := range fields {
+			if f == "" || len(f) > 2 || skipDigits(f, 0) != len(f) {
+				return 0, ErrBadFormat
+			}
+			n, _ := strconv.Atoi(f)
+			if (i == 0 && n >= 24) || n >= 60 {
+				return 0, ErrRange
+			}
+			d += time.Duration(n) * [...]time.Duration{time.Hour, time.Minute, time.Second}[i]
+		}
+
+		if hasFrac {
+			if skipDigits(frac, 0) != len(frac) {
+				return 0, ErrBadFormat
+			}
+			if len(frac) > 7 {
+				return 0, ErrRange
+			}
+			n, _ := strconv.Atoi(frac + "0000000"[len(frac):])
+			d += time.Duration(n) * tick
+		}
+		if d < 0 {
+			return 0, ErrRange
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
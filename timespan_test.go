@@ -0,0 +1,95 @@
+package duration
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTimeSpan(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  time.Duration
+		out string
+	}{
+		{0, "00:00:00"},
+		{90 * time.Minute, "01:30:00"},
+		{dayTime + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond, "1.02:03:04.5000000"},
+		{-(90 * time.Second), "-00:01:30"},
+		{123 * time.Nanosecond, "00:00:00.0000001"},
+		{99 * time.Nanosecond, "00:00:00"},
+		{400 * dayTime, "400.00:00:00"},
+		{time.Duration(math.MinInt64), "-106751.23:47:16.8547758"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, FormatTimeSpan(vec.in), vec.in)
+	}
+}
+
+func TestParseTimeSpan(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"00:00:00", 0},
+		{"01:30:00", 90 * time.Minute},
+		{"1:30", 90 * time.Minute},
+		{"1.02:03:04.5000000", dayTime + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{"1.02:03:04.5", dayTime + 2*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{"-00:01:30", -90 * time.Second},
+		{"3", 3 * dayTime},
+		{"-3", -3 * dayTime},
+		{"3.04:05", 3*dayTime + 4*time.Hour + 5*time.Minute},
+		{" 00:00:01 ", time.Second},
+		{"00:00:00.0000001", tick},
+	}
+
+	for _, vec := range vecs {
+		d, err := ParseTimeSpan(vec.in)
+		assert.NoError(t, err, vec.in)
+		assert.Equal(t, vec.out, d, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"", ErrBadFormat},
+		{"-", ErrBadFormat},
+		{"1.", ErrBadFormat},
+		{"1.2", ErrBadFormat},
+		{"01:30.5", ErrBadFormat},
+		{"01:30:00.", ErrBadFormat},
+		{"01:30:00:00", ErrBadFormat},
+		{"001:30:00", ErrBadFormat},
+		{"01::00", ErrBadFormat},
+		{"+01:00:00", ErrBadFormat},
+		{"PT1H", ErrBadFormat},
+		{"24:00:00", ErrRange},
+		{"01:60:00", ErrRange},
+		{"01:00:60", ErrRange},
+		{"00:00:00.00000001", ErrRange},
+		{"200000.00:00:00", ErrRange},
+	}
+
+	for _, vec := range bad {
+		_, err := ParseTimeSpan(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+	}
+}
+
+func TestTimeSpanRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"00:00:00", "1.02:03:04.5000000", "-400.23:59:59.9999999"} {
+		d, err := ParseTimeSpan(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, in, FormatTimeSpan(d))
+	}
+}
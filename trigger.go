@@ -0,0 +1,127 @@
+package duration
+
+import (
+	"strings"
+	"time"
+)
+
+// Related is the boundary of an event or to-do that an iCalendar alarm
+// trigger is relative to, as given by the RELATED parameter.
+type Related int
+
+const (
+	// RelatedStart relates the trigger to the start of the event. This is
+	// the default.
+	RelatedStart Related = iota
+
+	// RelatedEnd relates the trigger to the end of the event, or the due
+	// time of a to-do.
+	RelatedEnd
+)
+
+// triggerTime is the layout of a TRIGGER with VALUE=DATE-TIME, which must be
+// in UTC.
+const triggerTime = "20060102T150405Z"
+
+// Trigger is the TRIGGER property of an iCalendar VALARM component, which
+// says when an alarm fires.
+type Trigger struct {
+	// Offset is the time of the alarm relative to Related; negative offsets
+	// are before it (e.g. -15 minutes for "-PT15M").
+	Offset time.Duration
+
+	// Related is the boundary of the event that Offset is relative to.
+	Related Related
+
+	// Absolute, when not zero, is the time of an alarm given as a UTC
+	// DATE-TIME, in which case Offset and Related are unused.
+	Absolute time.Time
+}
+
+// ParseTrigger parses an iCalendar TRIGGER, either a whole content line such
+// as "TRIGGER;RELATED=END:PT5M" or the value alone such as "-PT15M". The
+// duration is read as by the "ical" profile, and the RELATED parameter and
+// absolute triggers with VALUE=DATE-TIME are supported; other parameters are
+// ignored. Invalid triggers fail with ErrBadFormat.
+func ParseTrigger(s string) (Trigger, error) {
+	var t Trigger
+	value := strings.TrimSpace(s)
+
+	var absolute, related bool
+	if name, rest, ok := strings.Cut(value, ":"); ok && strings.HasPrefix(strings.ToUpper(name), "TRIGGER") {
+		params := strings.Split(name, ";")
+		if !strings.EqualFold(params[0], "TRIGGER") {
+			return Trigger{}, ErrBadFormat
+		}
+		for _, p := range params[1:] {
+			k, v, _ := strings.Cut(p, "=")
+			v = strings.Trim(v, `"`)
+			switch strings.ToUpper(k) {
+			case "RELATED":
+				switch strings.ToUpper(v) {
+				case "START":
+					t.Related = RelatedStart
+				case "END":
+					t.Related = RelatedEnd
+				default:
+					return Trigger{}, ErrBadFormat
+				}
+				related = true
+			case "VALUE":
+				switch strings.ToUpper(v) {
+				case "DURATION":
+				case "DATE-TIME":
+					absolute = true
+				default:
+					return Trigger{}, ErrBadFormat
+				}
+			}
+		}
+		value = rest
+	}
+
+	if absolute {
+		if related {
+			return Trigger{}, ErrBadFormat
+		}
+		at, err := time.Parse(triggerTime, value)
+		if err != nil {
+			return Trigger{}, ErrBadFormat
+		}
+		t.Absolute = at
+		return t, nil
+	}
+
+	d, err := icalProfile.Parse(value)
+	if err != nil {
+		return Trigger{}, err
+	}
+	t.Offset = d
+	return t, nil
+}
+
+// Time returns the time at which the alarm fires for an event from start to
+// end: Absolute if set, otherwise Offset from start or end as Related says.
+func (t Trigger) Time(start, end time.Time) time.Time {
+	switch {
+	case !t.Absolute.IsZero():
+		return t.Absolute
+	case t.Related == RelatedEnd:
+		return end.Add(t.Offset)
+	}
+	return start.Add(t.Offset)
+}
+
+// String returns t as an iCalendar content line, e.g. "TRIGGER:-PT15M" or
+// "TRIGGER;RELATED=END:PT5M". Offsets are written as by the "ical" profile.
+func (t Trigger) String() string {
+	if !t.Absolute.IsZero() {
+		return "TRIGGER;VALUE=DATE-TIME:" + t.Absolute.UTC().Format(triggerTime)
+	}
+
+	s, _ := icalProfile.Format(t.Offset)
+	if t.Related == RelatedEnd {
+		return "TRIGGER;RELATED=END:" + s
+	}
+	return "TRIGGER:" + s
+}
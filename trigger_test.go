@@ -0,0 +1,57 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrigger(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(1998, 1, 1, 5, 0, 0, 0, time.UTC)
+	vecs := []struct {
+		in  string
+		out Trigger
+		str string
+		err error
+	}{
+		{"-PT15M", Trigger{Offset: -15 * time.Minute}, "TRIGGER:-PT15M", nil},
+		{"TRIGGER:-PT15M", Trigger{Offset: -15 * time.Minute}, "TRIGGER:-PT15M", nil},
+		{"TRIGGER;RELATED=END:PT5M", Trigger{Offset: 5 * time.Minute, Related: RelatedEnd}, "TRIGGER;RELATED=END:PT5M", nil},
+		{"trigger;related=start:-P1D", Trigger{Offset: -dayTime}, "TRIGGER:-P1D", nil},
+		{"TRIGGER;VALUE=DURATION;RELATED=\"END\":+P1W", Trigger{Offset: weekTime, Related: RelatedEnd}, "TRIGGER;RELATED=END:P1W", nil},
+		{"TRIGGER;X-FOO=bar:PT0S", Trigger{}, "TRIGGER:PT0S", nil},
+		{"TRIGGER;VALUE=DATE-TIME:19980101T050000Z", Trigger{Absolute: at}, "TRIGGER;VALUE=DATE-TIME:19980101T050000Z", nil},
+		{"TRIGGER;VALUE=DATE-TIME;RELATED=END:19980101T050000Z", Trigger{}, "", ErrBadFormat},
+		{"TRIGGER;VALUE=DATE-TIME:19980101T050000", Trigger{}, "", ErrBadFormat},
+		{"TRIGGER;VALUE=DATE:19980101", Trigger{}, "", ErrBadFormat},
+		{"TRIGGER;RELATED=MIDDLE:PT5M", Trigger{}, "", ErrBadFormat},
+		{"TRIGGERS:PT5M", Trigger{}, "", ErrBadFormat},
+		{"TRIGGER:PT1.5M", Trigger{}, "", ErrBadFormat},
+		{"TRIGGER:P1Y", Trigger{}, "", ErrBadFormat},
+		{"15 minutes before", Trigger{}, "", ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		tr, err := ParseTrigger(vec.in)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		assert.Equal(t, vec.out, tr, vec.in)
+		if err == nil {
+			assert.Equal(t, vec.str, tr.String(), vec.in)
+		}
+	}
+}
+
+func TestTriggerTime(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	at := time.Date(2024, 4, 30, 18, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, start.Add(-15*time.Minute), Trigger{Offset: -15 * time.Minute}.Time(start, end))
+	assert.Equal(t, end.Add(5*time.Minute), Trigger{Offset: 5 * time.Minute, Related: RelatedEnd}.Time(start, end))
+	assert.Equal(t, at, Trigger{Absolute: at, Offset: time.Hour}.Time(start, end))
+}
@@ -0,0 +1,42 @@
+package duration
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration is a time.Duration that renders itself as an ISO8601 duration. It
+// converts freely to and from time.Duration and exists so that values can
+// carry the methods needed by fmt and encoding packages.
+type Duration time.Duration
+
+// String returns the ISO8601 representation of d as from Format. Negative
+// durations are written with a leading "-", e.g. "-PT1S".
+func (d Duration) String() string {
+	s, _ := signedFormat.format(time.Duration(d))
+	return s
+}
+
+// Format implements fmt.Formatter. The verbs %v and %s write the ISO8601
+// representation, %q writes it quoted, %h writes it humanized as from
+// Humanize and %g writes it as from time.Duration.String. Width and flags
+// are honored as for strings.
+func (d Duration) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 'v', 's', 'q':
+		s = d.String()
+	case 'h':
+		s = Humanize(time.Duration(d))
+	case 'g':
+		s = time.Duration(d).String()
+	default:
+		fmt.Fprintf(f, "%%!%c(duration.Duration=%s)", verb, d.String())
+		return
+	}
+
+	if verb != 'q' {
+		verb = 's'
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), s)
+}
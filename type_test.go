@@ -0,0 +1,43 @@
+package duration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "PT1H30M", Duration(90*time.Minute).String())
+	assert.Equal(t, "P0Y", Duration(0).String())
+	assert.Equal(t, "-PT1.500S", Duration(-1500*time.Millisecond).String())
+}
+
+func TestDurationFormat(t *testing.T) {
+	t.Parallel()
+
+	d := Duration(90 * time.Minute)
+
+	vecs := []struct {
+		format string
+		out    string
+	}{
+		{"%v", "PT1H30M"},
+		{"%s", "PT1H30M"},
+		{"%q", `"PT1H30M"`},
+		{"%h", "1 hour, 30 minutes"},
+		{"%g", "1h30m0s"},
+		{"%10v", "   PT1H30M"},
+		{"%-10v|", "PT1H30M   |"},
+		{"%d", "%!d(duration.Duration=PT1H30M)"},
+	}
+
+	for _, vec := range vecs {
+		assert.Equal(t, vec.out, fmt.Sprintf(vec.format, d), vec.format)
+	}
+
+	assert.Equal(t, "timeout=PT5S", fmt.Sprint("timeout=", Duration(5*time.Second)))
+}
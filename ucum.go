@@ -0,0 +1,131 @@
+package duration
+
+import "math"
+
+// UCUMQuantity is a time quantity coded in UCUM, the Unified Code for Units of
+// Measure, as carried by the FHIR Duration type. Unit is the UCUM code, which
+// FHIR stores in the code element (e.g. {90, "min"}).
+type UCUMQuantity struct {
+	Value float64
+	Unit  string
+}
+
+// UCUMPolicy selects how the UCUM month ("mo") and year ("a") units relate to
+// ISO8601 elements. UCUM defines them as averages of the Julian calendar,
+// while ISO8601 months and years are calendar units of varying length.
+type UCUMPolicy int
+
+const (
+	// UCUMCalendar maps "mo" and "a" to month and year elements, so that
+	// {3, "mo"} is "P3M". Quantities of other units convert to days, weeks,
+	// hours, minutes and seconds, and components mixing the two fail with
+	// ErrBadFormat. This is the policy for clinical intervals such as dosing
+	// periods, which follow the calendar.
+	UCUMCalendar UCUMPolicy = iota
+
+	// UCUMJulian converts "mo" and "a" to days using the UCUM definitions of
+	// 30.4375 and 365.25 days, so that {1, "a"} is "P365.25D", and months and
+	// years in components are converted the same way.
+	UCUMJulian
+)
+
+// ucumUnits are the UCUM time units, largest first, with their lengths in
+// seconds and the element they map to.
+var ucumUnits = [...]struct {
+	code string
+	secs float64
+	unit Unit
+}{
+	{"a", 365.25 * 86400, Year},
+	{"mo", 30.4375 * 86400, Month},
+	{"wk", 7 * 86400, Week},
+	{"d", 86400, Day},
+	{"h", 3600, Hour},
+	{"min", 60, Minute},
+	{"s", 1, Second},
+	{"ms", 0.001, Second},
+}
+
+// FromUCUM converts q, a quantity in one of the UCUM time units "a", "mo",
+// "wk", "d", "h", "min", "s" or "ms", to Components, applying policy to months
+// and years. Other units and non-finite values fail with ErrBadFormat. Use
+// FormatComponents to write the result in ISO8601.
+func FromUCUM(q UCUMQuantity, policy UCUMPolicy) (Components, error) {
+	if math.IsNaN(q.Value) || math.IsInf(q.Value, 0) {
+		return Components{}, ErrBadFormat
+	}
+
+	for _, u := range ucumUnits {
+		if u.code != q.Unit {
+			continue
+		}
+
+		var c Components
+		switch {
+		case u.unit <= Month && policy == UCUMJulian:
+			c.Days = q.Value * u.secs / 86400
+		case u.code == "ms":
+			c.Seconds = q.Value / 1000
+		default:
+			*c.field(u.unit) = q.Value
+		}
+		return c, nil
+	}
+	return Components{}, ErrBadFormat
+}
+
+// ToUCUM converts c to a single UCUM quantity, using the largest unit down to
+// milliseconds in which the value is a whole number, or seconds if there is
+// none (e.g. {90, "min"} for "PT1H30M", {1500, "ms"} for "PT1.5S" and
+// {1.2345, "s"} for "PT1.2345S"), and {0, "s"} for zero. Days are 24 hours.
+// Under UCUMCalendar, months and years become "mo" or "a" and cannot be
+// combined with other elements; under UCUMJulian they are converted to their
+// UCUM lengths. Non-finite fields fail with ErrBadFormat.
+func ToUCUM(c Components, policy UCUMPolicy) (UCUMQuantity, error) {
+	var cal, secs float64
+	for u := Year; u < numUnits; u++ {
+		v := *c.field(u)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return UCUMQuantity{}, ErrBadFormat
+		}
+		switch {
+		case u == Year && policy == UCUMCalendar:
+			cal += v * 12
+		case u == Month && policy == UCUMCalendar:
+			cal += v
+		case u <= Month:
+			secs += v * ucumUnits[u].secs
+		default:
+			secs += v * unitTimes[u].Seconds()
+		}
+	}
+
+	if cal != 0 {
+		switch {
+		case secs != 0:
+			return UCUMQuantity{}, ErrBadFormat
+		case math.Mod(cal, 12) == 0:
+			return UCUMQuantity{cal / 12, "a"}, nil
+		}
+		return UCUMQuantity{cal, "mo"}, nil
+	}
+
+	if secs == 0 {
+		return UCUMQuantity{0, "s"}, nil
+	}
+
+	units := ucumUnits[:]
+	if policy == UCUMCalendar {
+		units = units[2:]
+	}
+	for _, u := range units {
+		v := secs / u.secs
+		if u.code == "ms" {
+			v = secs * 1000
+		}
+		if v == math.Trunc(v) {
+			return UCUMQuantity{v, u.code}, nil
+		}
+	}
+	return UCUMQuantity{secs, "s"}, nil
+}
@@ -0,0 +1,74 @@
+package duration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromUCUM(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in     UCUMQuantity
+		policy UCUMPolicy
+		out    Components
+		err    error
+	}{
+		{UCUMQuantity{90, "min"}, UCUMCalendar, Components{Minutes: 90}, nil},
+		{UCUMQuantity{2, "wk"}, UCUMCalendar, Components{Weeks: 2}, nil},
+		{UCUMQuantity{1.5, "h"}, UCUMCalendar, Components{Hours: 1.5}, nil},
+		{UCUMQuantity{500, "ms"}, UCUMCalendar, Components{Seconds: 0.5}, nil},
+		{UCUMQuantity{3, "mo"}, UCUMCalendar, Components{Months: 3}, nil},
+		{UCUMQuantity{1, "a"}, UCUMCalendar, Components{Years: 1}, nil},
+		{UCUMQuantity{3, "d"}, UCUMJulian, Components{Days: 3}, nil},
+		{UCUMQuantity{2, "mo"}, UCUMJulian, Components{Days: 60.875}, nil},
+		{UCUMQuantity{1, "a"}, UCUMJulian, Components{Days: 365.25}, nil},
+		{UCUMQuantity{1, "min "}, UCUMCalendar, Components{}, ErrBadFormat},
+		{UCUMQuantity{1, "Min"}, UCUMCalendar, Components{}, ErrBadFormat},
+		{UCUMQuantity{1, "m"}, UCUMCalendar, Components{}, ErrBadFormat},
+		{UCUMQuantity{math.NaN(), "s"}, UCUMCalendar, Components{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		c, err := FromUCUM(vec.in, vec.policy)
+		assert.ErrorIs(t, err, vec.err, "%v", vec.in)
+		assert.Equal(t, vec.out, c, "%v", vec.in)
+	}
+}
+
+func TestToUCUM(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in     Components
+		policy UCUMPolicy
+		out    UCUMQuantity
+		err    error
+	}{
+		{Components{Hours: 1, Minutes: 30}, UCUMCalendar, UCUMQuantity{90, "min"}, nil},
+		{Components{Days: 14}, UCUMCalendar, UCUMQuantity{2, "wk"}, nil},
+		{Components{Days: 1, Hours: 12}, UCUMCalendar, UCUMQuantity{36, "h"}, nil},
+		{Components{Seconds: 1.5}, UCUMCalendar, UCUMQuantity{1500, "ms"}, nil},
+		{Components{Seconds: 1.2345}, UCUMCalendar, UCUMQuantity{1.2345, "s"}, nil},
+		{Components{Seconds: 0.0005}, UCUMCalendar, UCUMQuantity{0.0005, "s"}, nil},
+		{Components{Months: 3}, UCUMCalendar, UCUMQuantity{3, "mo"}, nil},
+		{Components{Years: 1, Months: 6}, UCUMCalendar, UCUMQuantity{18, "mo"}, nil},
+		{Components{Years: 2}, UCUMCalendar, UCUMQuantity{2, "a"}, nil},
+		{Components{Months: 24}, UCUMCalendar, UCUMQuantity{2, "a"}, nil},
+		{Components{}, UCUMCalendar, UCUMQuantity{0, "s"}, nil},
+		{Components{Years: 1}, UCUMJulian, UCUMQuantity{1, "a"}, nil},
+		{Components{Months: 1}, UCUMJulian, UCUMQuantity{1, "mo"}, nil},
+		{Components{Days: 365}, UCUMJulian, UCUMQuantity{365, "d"}, nil},
+		{Components{Years: 1, Days: 1}, UCUMJulian, UCUMQuantity{8790, "h"}, nil},
+		{Components{Months: 1, Days: 1}, UCUMCalendar, UCUMQuantity{}, ErrBadFormat},
+		{Components{Seconds: math.Inf(1)}, UCUMCalendar, UCUMQuantity{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		q, err := ToUCUM(vec.in, vec.policy)
+		assert.ErrorIs(t, err, vec.err, "%+v", vec.in)
+		assert.Equal(t, vec.out, q, "%+v", vec.in)
+	}
+}
@@ -0,0 +1,53 @@
+package duration
+
+import "time"
+
+// Unit identifies the designator of a duration element. Units are ordered
+// from most to least significant.
+type Unit int
+
+const (
+	Year Unit = iota
+	Month
+	Week
+	Day
+	Hour
+	Minute
+	Second
+	numUnits
+)
+
+// unitTimes maps each unit to its nominal length. Months have no fixed length
+// and are never converted.
+var unitTimes = [numUnits]time.Duration{
+	Year:   yearTime,
+	Week:   weekTime,
+	Day:    dayTime,
+	Hour:   time.Hour,
+	Minute: time.Minute,
+	Second: time.Second,
+}
+
+var unitNames = [numUnits]string{"year", "month", "week", "day", "hour", "minute", "second"}
+
+// String returns the lower-case English name of u, e.g. "minute".
+func (u Unit) String() string {
+	if u < 0 || u >= numUnits {
+		return "unknown"
+	}
+	return unitNames[u]
+}
+
+// Designator returns the character used for u in a duration string, e.g. 'M'
+// for both Month and Minute.
+func (u Unit) Designator() byte {
+	if u < 0 || u >= numUnits {
+		return '?'
+	}
+	return "YMWDHMS"[u]
+}
+
+// isTime reports whether u belongs after the "T" separator.
+func (u Unit) isTime() bool {
+	return u >= Hour
+}
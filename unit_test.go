@@ -0,0 +1,20 @@
+package duration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "year", Year.String())
+	assert.Equal(t, "minute", Minute.String())
+	assert.Equal(t, "unknown", Unit(-1).String())
+
+	assert.Equal(t, byte('M'), Month.Designator())
+	assert.Equal(t, byte('M'), Minute.Designator())
+	assert.Equal(t, byte('W'), Week.Designator())
+	assert.Equal(t, byte('?'), numUnits.Designator())
+}
@@ -0,0 +1,42 @@
+package duration
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MarshalXML implements xml.Marshaler, encoding d as element text in the
+// lexical form of xs:duration (e.g. <timeout>PT1H30M</timeout>).
+func (d Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := signedFormat.append(nil, time.Duration(d))
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(b), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, parsing element text as
+// UnmarshalText does. Surrounding white space is ignored.
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, encoding d as an attribute
+// value (e.g. mediaPresentationDuration="PT9M56.460S" in a DASH manifest).
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := signedFormat.append(nil, time.Duration(d))
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(b)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, parsing the attribute
+// value as UnmarshalText does.
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
@@ -0,0 +1,75 @@
+package duration
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlMPD struct {
+	XMLName  xml.Name `xml:"MPD"`
+	Duration Duration `xml:"mediaPresentationDuration,attr"`
+	Buffer   Duration `xml:"minBufferTime,attr,omitempty"`
+	Period   Duration `xml:"Period"`
+}
+
+func TestDurationMarshalXML(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  xmlMPD
+		out string
+	}{
+		{
+			xmlMPD{Duration: Duration(9*time.Minute + 56460*time.Millisecond), Buffer: Duration(2 * time.Second), Period: Duration(time.Hour)},
+			`<MPD mediaPresentationDuration="PT9M56.460S" minBufferTime="PT2S"><Period>PT1H</Period></MPD>`,
+		},
+		{
+			xmlMPD{Duration: Duration(-time.Second)},
+			`<MPD mediaPresentationDuration="-PT1S"><Period>P0Y</Period></MPD>`,
+		},
+	}
+
+	for _, vec := range vecs {
+		b, err := xml.Marshal(vec.in)
+		require.NoError(t, err)
+		assert.Equal(t, vec.out, string(b))
+	}
+}
+
+func TestDurationUnmarshalXML(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out xmlMPD
+		err error
+	}{
+		{
+			`<MPD mediaPresentationDuration="PT0H9M56.46S"><Period> PT1H </Period></MPD>`,
+			xmlMPD{Duration: Duration(9*time.Minute + 56460*time.Millisecond), Period: Duration(time.Hour)},
+			nil,
+		},
+		{
+			`<MPD mediaPresentationDuration="-P1D"></MPD>`,
+			xmlMPD{Duration: Duration(-24 * time.Hour)},
+			nil,
+		},
+		{`<MPD mediaPresentationDuration="1h"></MPD>`, xmlMPD{}, ErrBadFormat},
+		{`<MPD><Period>P1M</Period></MPD>`, xmlMPD{}, ErrNoMonth},
+		{`<MPD><Period><x/></Period></MPD>`, xmlMPD{}, ErrBadFormat},
+	}
+
+	for _, vec := range vecs {
+		var mpd xmlMPD
+		err := xml.Unmarshal([]byte(vec.in), &mpd)
+		assert.ErrorIs(t, err, vec.err, vec.in)
+		if vec.err == nil {
+			mpd.XMLName = xml.Name{}
+			assert.Equal(t, vec.out, mpd, vec.in)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package duration
+
+import "time"
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, encoding d as an ISO8601 string.
+func (d Duration) MarshalYAML() (any, error) {
+	b, err := signedFormat.append(nil, time.Duration(d))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 also honors. It accepts an ISO8601 string, quoted or
+// bare. Other scalars, including the sexagesimal numbers of YAML 1.1 such as
+// 1:30:00, fail with ErrBadFormat rather than being read as seconds.
+func (d *Duration) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return ErrBadFormat
+	}
+	v, err := parseSigned(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
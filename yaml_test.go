@@ -0,0 +1,62 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlConfig struct {
+	Timeout Duration `yaml:"timeout"`
+}
+
+func TestDurationMarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	b, err := yaml.Marshal(yamlConfig{Duration(90 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, "timeout: PT1H30M\n", string(b))
+
+	b, err = yaml.Marshal(yamlConfig{Duration(-time.Second)})
+	assert.NoError(t, err)
+	assert.Equal(t, "timeout: -PT1S\n", string(b))
+}
+
+func TestDurationUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	vecs := []struct {
+		in  string
+		out Duration
+	}{
+		{"timeout: PT1H30M", Duration(90 * time.Minute)},
+		{`timeout: "PT1H30M"`, Duration(90 * time.Minute)},
+		{"timeout: 'P1D'", Duration(dayTime)},
+		{"timeout: -PT1S", Duration(-time.Second)},
+	}
+
+	for _, vec := range vecs {
+		var c yamlConfig
+		assert.NoError(t, yaml.Unmarshal([]byte(vec.in), &c), vec.in)
+		assert.Equal(t, vec.out, c.Timeout, vec.in)
+	}
+
+	bad := []struct {
+		in  string
+		err error
+	}{
+		{"timeout: 1:30:00", ErrBadFormat},
+		{"timeout: 190:20:30", ErrBadFormat},
+		{"timeout: 5400", ErrBadFormat},
+		{"timeout: 1h30m", ErrBadFormat},
+		{"timeout: [PT1H]", ErrBadFormat},
+		{"timeout: P1M", ErrNoMonth},
+	}
+
+	for _, vec := range bad {
+		var c yamlConfig
+		assert.ErrorIs(t, yaml.Unmarshal([]byte(vec.in), &c), vec.err, vec.in)
+	}
+}